@@ -0,0 +1,58 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock represents a source of the current time.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+}
+
+// ClockFunc is an adapter to allow the use of ordinary functions as [Clock].
+type ClockFunc func() time.Time
+
+// Now returns the result of calling the function.
+func (fn ClockFunc) Now() time.Time { return fn() }
+
+// SystemClock returns a clock which returns the current system time.
+func SystemClock() Clock { return ClockFunc(time.Now) }
+
+// SettableClock is a [Clock] returning the time which was set. It is safe for
+// concurrent use and is meant to be used in tests.
+type SettableClock struct {
+	now time.Time  // Current time.
+	mx  sync.Mutex // Guards the current time.
+}
+
+// NewSettableClock returns a new instance of [SettableClock] set to the given
+// time.
+func NewSettableClock(now time.Time) *SettableClock {
+	return &SettableClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *SettableClock) Now() time.Time {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	return c.now
+}
+
+// Set sets the current time of the clock.
+func (c *SettableClock) Set(now time.Time) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.now = now
+}
+
+// Advance moves the current time of the clock by the given duration.
+func (c *SettableClock) Advance(d time.Duration) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	c.now = c.now.Add(d)
+}
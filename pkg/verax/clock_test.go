@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ctx42/testing/pkg/assert"
+)
+
+func Test_ClockFunc_Now(t *testing.T) {
+	// --- Given ---
+	tim := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := ClockFunc(func() time.Time { return tim })
+
+	// --- When ---
+	have := clock.Now()
+
+	// --- Then ---
+	assert.Equal(t, tim, have)
+}
+
+func Test_SystemClock(t *testing.T) {
+	// --- Given ---
+	clock := SystemClock()
+
+	// --- When ---
+	have := clock.Now()
+
+	// --- Then ---
+	assert.Within(t, time.Now(), "1s", have)
+}
+
+func Test_NewSettableClock(t *testing.T) {
+	// --- Given ---
+	tim := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// --- When ---
+	have := NewSettableClock(tim)
+
+	// --- Then ---
+	assert.Equal(t, tim, have.Now())
+}
+
+func Test_SettableClock_Set(t *testing.T) {
+	// --- Given ---
+	clock := NewSettableClock(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+	tim := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// --- When ---
+	clock.Set(tim)
+
+	// --- Then ---
+	assert.Equal(t, tim, clock.Now())
+}
+
+func Test_SettableClock_Advance(t *testing.T) {
+	// --- Given ---
+	clock := NewSettableClock(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	// --- When ---
+	clock.Advance(time.Hour)
+
+	// --- Then ---
+	want := time.Date(2025, 1, 2, 4, 4, 5, 0, time.UTC)
+	assert.Equal(t, want, clock.Now())
+}
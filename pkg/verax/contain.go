@@ -10,8 +10,9 @@ import (
 	"github.com/ctx42/xrr/pkg/xrr"
 )
 
-// Contain returns a validation rule that loops through iterable (map, slice
-// or array) and validates it contains at least one given value.
+// Contain returns a validation rule that loops through iterable (map, slice,
+// array, [iter.Seq] or [iter.Seq2]) and validates it contains at least one
+// given value. Iterators are consumed only up to the first matching value.
 func Contain(rule EqualRule) ContainRule { return ContainRule(rule) }
 
 // ContainRule is a validation rule that validates there is at least one
@@ -41,6 +42,15 @@ func (r ContainRule) Validate(v any) error {
 			}
 		}
 
+	case reflect.Func:
+		check := func(_ string, val reflect.Value) bool {
+			success = Validate(getInterface(val), EqualRule(r)) == nil
+			return !success
+		}
+		if !rangeFunc(vo, check) {
+			return xrr.New("must be an iterable", ECInvType)
+		}
+
 	default:
 		return xrr.New("must be an iterable", ECInvType)
 	}
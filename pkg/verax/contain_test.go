@@ -4,6 +4,9 @@
 package verax
 
 import (
+	"iter"
+	"maps"
+	"slices"
 	"testing"
 
 	"github.com/ctx42/testing/pkg/assert"
@@ -24,6 +27,9 @@ func Test_ContainRule_Validate_valid_tabular(t *testing.T) {
 
 		{"map string:int", map[string]int{"A": 1, "B": 2, "C": 3}, Equal(2)},
 		{"map int:string", map[int]string{1: "A", 2: "B", 3: "C"}, Equal("C")},
+
+		{"iter.Seq of int", slices.Values([]int{1, 2, 3}), Equal(2)},
+		{"iter.Seq2 int:string", slices.All([]string{"A", "B"}), Equal("B")},
 	}
 
 	for _, tc := range tt {
@@ -101,6 +107,34 @@ func Test_ContainRule_Validate_invalid_tabular(t *testing.T) {
 			"must contain at least one 'D' value",
 			ECNotEqual,
 		},
+		{
+			"iter.Seq does not contain",
+			slices.Values([]int{1, 2, 3}),
+			Equal(4),
+			"must contain at least one '4' value",
+			ECNotEqual,
+		},
+		{
+			"nil iter.Seq",
+			iter.Seq[int](nil),
+			Equal(4),
+			"must contain at least one '4' value",
+			ECNotEqual,
+		},
+		{
+			"iter.Seq2 does not contain",
+			maps.All(map[string]int{"A": 1}),
+			Equal(4),
+			"must contain at least one '4' value",
+			ECNotEqual,
+		},
+		{
+			"function is not iterable",
+			func() {},
+			Equal("C"),
+			"must be an iterable",
+			ECInvType,
+		},
 		{
 			"must be iterable",
 			"ABC",
@@ -121,3 +155,23 @@ func Test_ContainRule_Validate_invalid_tabular(t *testing.T) {
 		})
 	}
 }
+
+func Test_ContainRule_Validate_iterator(t *testing.T) {
+	// --- Given ---
+	var yielded []int
+	seq := func(yield func(int) bool) {
+		for i := range 5 {
+			yielded = append(yielded, i)
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	// --- When ---
+	err := Contain(Equal(1)).Validate(iter.Seq[int](seq))
+
+	// --- Then ---
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 1}, yielded)
+}
@@ -4,59 +4,234 @@
 package verax
 
 import (
+	"maps"
+	"math"
 	"reflect"
+	"slices"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/ctx42/xrr/pkg/xrr"
 )
 
-// Each returns a validation rule that loops through an iterable (map, slice or
-// array) and validates each value inside with the provided rules. Empty
-// iterable is considered valid. Use the [Required] rule to make sure the
-// iterable is not empty.
+// Each returns a validation rule that loops through an iterable (map, slice,
+// array, [iter.Seq] or [iter.Seq2]) and validates each value inside with the
+// provided rules. Empty iterable is considered valid. Use the [Required] rule
+// to make sure the iterable is not empty.
+//
+// Values yielded by [iter.Seq] are reported under their positions, values
+// yielded by [iter.Seq2] are reported under their keys. Iterators are consumed
+// once, so streaming sources can be validated without loading all the values
+// into memory.
 func Each(rules ...Rule) EachRule { return EachRule{rules: rules} }
 
+// EachKey returns a validation rule that loops through a map and validates
+// each key with the provided rules. Key errors are reported under the map key
+// prefixed with [EachKeyPrefix]. An empty map is considered valid. For all
+// other types, the rule returns an error.
+func EachKey(rules ...Rule) EachRule {
+	return EachRule{keyRules: rules, keys: true, skipValues: true}
+}
+
+// EachEntry returns a validation rule that loops through a map and validates
+// each key with the keyRules and each value with the valueRules. Key errors
+// are reported under the map key prefixed with [EachKeyPrefix], value errors
+// are reported under the map key. An empty map is considered valid. For all
+// other types, the rule returns an error.
+func EachEntry(keyRules, valueRules []Rule) EachRule {
+	return EachRule{rules: valueRules, keyRules: keyRules, keys: true}
+}
+
+// EachKeyPrefix is the prefix of the error field name for map key errors
+// reported by the [EachKey] and [EachEntry] rules.
+const EachKeyPrefix = "key:"
+
 // EachRule is a validation rule that validates elements in a map/slice/array
 // using the specified list of rules.
 type EachRule struct {
-	rules []Rule
+	rules      []Rule // Value rules.
+	keyRules   []Rule // Map key rules.
+	keys       bool   // Validate map keys, only maps are supported.
+	skipValues bool   // Do not validate map values.
+	workers    int    // Number of goroutines validating elements.
+	failFast   int    // Maximum number of invalid elements to report.
+}
+
+// Parallel configures the rule to validate elements using the given number of
+// goroutines. It is useful for large iterables and expensive rules. The rules
+// must be safe for concurrent use. The result is the same as the result of
+// the sequential validation. Values smaller than 2 disable parallel
+// validation.
+func (r EachRule) Parallel(workers int) EachRule {
+	r.workers = workers
+	return r
+}
+
+// FailFast configures the rule to stop validation after n invalid elements.
+// Elements are validated in order (maps in the order of their sorted keys),
+// so the first n invalid elements are always reported, also when used with
+// [EachRule.Parallel]. Values smaller than 1 disable the limit.
+func (r EachRule) FailFast(n int) EachRule {
+	r.failFast = n
+	return r
 }
 
 // Validate loops through the given iterable and calls the Validate() method
 // for each value.
 func (r EachRule) Validate(v any) error {
-	var ers xrr.Fields
-
 	vo := reflect.ValueOf(v)
+	if r.keys && vo.Kind() != reflect.Map {
+		return xrr.New("must be a map", ECInvType)
+	}
+
+	var walk eachWalker
 	switch vo.Kind() {
 	case reflect.Map:
-		for _, k := range vo.MapKeys() {
-			val := getInterface(vo.MapIndex(k))
-			if err := Validate(val, r.rules...); err != nil {
-				if ers == nil {
-					ers = xrr.Fields{}
+		walk = func(fn eachFunc) {
+			for _, k := range sortedMapKeys(vo) {
+				if !fn(mapErrKey(k), k, vo.MapIndex(k)) {
+					return
 				}
-				ers[mapErrKey(k)] = err
 			}
 		}
 
 	case reflect.Slice, reflect.Array:
-		for i := 0; i < vo.Len(); i++ {
-			val := getInterface(vo.Index(i))
-			if err := Validate(val, r.rules...); err != nil {
-				if ers == nil {
-					ers = xrr.Fields{}
+		walk = func(fn eachFunc) {
+			for i := 0; i < vo.Len(); i++ {
+				if !fn(strconv.Itoa(i), reflect.Value{}, vo.Index(i)) {
+					return
 				}
-				ers[strconv.Itoa(i)] = err
 			}
 		}
 
+	case reflect.Func:
+		if seqLen(vo.Type()) == 0 {
+			return xrr.New("must be an iterable", ECInvType)
+		}
+		walk = func(fn eachFunc) {
+			rangeFunc(vo, func(name string, val reflect.Value) bool {
+				return fn(name, reflect.Value{}, val)
+			})
+		}
+
 	default:
 		return xrr.New("must be an iterable", ECInvType)
 	}
 
+	var ers xrr.Fields
+	if r.workers > 1 {
+		ers = r.parallel(walk)
+	} else {
+		var failed int
+		walk(func(name string, key, val reflect.Value) bool {
+			if r.check(name, key, val, &ers) {
+				failed++
+			}
+			return r.failFast < 1 || failed < r.failFast
+		})
+	}
+
 	if len(ers) > 0 {
 		return ers
 	}
 	return nil
 }
+
+// eachFunc is called with the name, the map key (invalid for other iterables)
+// and the value of an element. Returns false to stop the iteration.
+type eachFunc func(name string, key, val reflect.Value) bool
+
+// eachWalker calls fn for each element of an iterable in order.
+type eachWalker func(fn eachFunc)
+
+// check validates the element key and value, adding errors to ers. Returns
+// true if the element is invalid.
+func (r EachRule) check(
+	name string,
+	key, val reflect.Value,
+	ers *xrr.Fields,
+) bool {
+
+	var failed bool
+	if r.keys {
+		if err := Validate(getInterface(key), r.keyRules...); err != nil {
+			xrr.AddField(ers, EachKeyPrefix+name, err)
+			failed = true
+		}
+	}
+	if !r.skipValues {
+		if err := Validate(getInterface(val), r.rules...); err != nil {
+			xrr.AddField(ers, name, err)
+			failed = true
+		}
+	}
+	return failed
+}
+
+// eachJob represents an element to validate.
+type eachJob struct {
+	pos      int           // Element position.
+	name     string        // Element name.
+	key, val reflect.Value // Element map key and value.
+}
+
+// parallel validates elements using multiple goroutines. Returns the same
+// errors as the sequential validation.
+func (r EachRule) parallel(walk eachWalker) xrr.Fields {
+	var mx sync.Mutex
+	failed := make(map[int]xrr.Fields) // Errors by the element position.
+
+	// Elements from this position are skipped.
+	var stop atomic.Int64
+	stop.Store(math.MaxInt64)
+
+	jobs := make(chan eachJob)
+	var wg sync.WaitGroup
+	for range r.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if int64(job.pos) >= stop.Load() {
+					continue
+				}
+				var ers xrr.Fields
+				if !r.check(job.name, job.key, job.val, &ers) {
+					continue
+				}
+				mx.Lock()
+				failed[job.pos] = ers
+				if r.failFast > 0 && len(failed) >= r.failFast {
+					// Elements past the n-th invalid one are not reported.
+					pos := slices.Sorted(maps.Keys(failed))
+					stop.Store(min(stop.Load(), int64(pos[r.failFast-1]+1)))
+				}
+				mx.Unlock()
+			}
+		}()
+	}
+
+	var pos int
+	walk(func(name string, key, val reflect.Value) bool {
+		if int64(pos) >= stop.Load() {
+			return false
+		}
+		jobs <- eachJob{pos: pos, name: name, key: key, val: val}
+		pos++
+		return true
+	})
+	close(jobs)
+	wg.Wait()
+
+	var ers xrr.Fields
+	for i, p := range slices.Sorted(maps.Keys(failed)) {
+		if r.failFast > 0 && i >= r.failFast {
+			break
+		}
+		for name, err := range failed[p] {
+			xrr.AddField(&ers, name, err)
+		}
+	}
+	return ers
+}
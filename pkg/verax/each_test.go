@@ -5,6 +5,12 @@ package verax
 
 import (
 	"errors"
+	"iter"
+	"maps"
+	"regexp"
+	"runtime"
+	"slices"
+	"sync"
 	"testing"
 
 	"github.com/ctx42/testing/pkg/assert"
@@ -73,6 +79,26 @@ func Test_EachRule_Validate_valid_tabular(t *testing.T) {
 			[]func(any) bool{iFunc},
 			[]Rule{Required},
 		},
+		{
+			"iter.Seq empty",
+			slices.Values([]string{}),
+			[]Rule{Required},
+		},
+		{
+			"iter.Seq with values",
+			slices.Values([]string{"abc", "def"}),
+			[]Rule{Required},
+		},
+		{
+			"iter.Seq nil",
+			iter.Seq[string](nil),
+			[]Rule{Required},
+		},
+		{
+			"iter.Seq2 with values",
+			maps.All(map[string]string{"key0": "val0", "key1": "val1"}),
+			[]Rule{Required},
+		},
 	}
 
 	for _, tc := range tt {
@@ -172,6 +198,36 @@ func Test_EachRule_Validate_invalid_tabular(t *testing.T) {
 			[]Rule{Required},
 			"0: cannot be blank (ECRequired); 1: cannot be blank (ECRequired)",
 		},
+		{
+			"function is not an iterator",
+			iFunc,
+			[]Rule{},
+			"must be an iterable (ECInvType)",
+		},
+		{
+			"iter.Seq with values",
+			slices.Values([]string{"def", "", ""}),
+			[]Rule{Required},
+			"1: cannot be blank (ECRequired); 2: cannot be blank (ECRequired)",
+		},
+		{
+			"iter.Seq with validators",
+			slices.Values([]ModelVal{{"abc"}, {"def"}}),
+			[]Rule{Required},
+			"1.FStr: must be 'abc' (ECMustAbc)",
+		},
+		{
+			"iter.Seq2 with keys",
+			maps.All(map[string]string{"key0": "val0", "key1": ""}),
+			[]Rule{Required},
+			"key1: cannot be blank (ECRequired)",
+		},
+		{
+			"iter.Seq2 with indexes",
+			slices.All([]string{"abc", ""}),
+			[]Rule{Required},
+			"1: cannot be blank (ECRequired)",
+		},
 	}
 
 	for _, tc := range tt {
@@ -232,3 +288,377 @@ func Test_Each(t *testing.T) {
 		xrrtest.AssertEqual(t, "1: error (ECGeneric)", err)
 	})
 }
+
+func Test_Each_iterator(t *testing.T) {
+	t.Run("values are streamed", func(t *testing.T) {
+		// --- Given ---
+		var yielded []int
+		seq := func(yield func(int) bool) {
+			for i := range 3 {
+				yielded = append(yielded, i)
+				if !yield(i) {
+					return
+				}
+			}
+		}
+
+		// --- When ---
+		err := Each(Max(1)).Validate(iter.Seq[int](seq))
+
+		// --- Then ---
+		wMsg := "2: must be no greater than 1 (ECInvThreshold)"
+		xrrtest.AssertEqual(t, wMsg, err)
+		assert.Equal(t, []int{0, 1, 2}, yielded)
+	})
+
+	t.Run("not named iterator type", func(t *testing.T) {
+		// --- Given ---
+		seq := func(yield func(string, int) bool) { yield("a", 2) }
+
+		// --- When ---
+		err := Each(Max(1)).Validate(seq)
+
+		// --- Then ---
+		wMsg := "a: must be no greater than 1 (ECInvThreshold)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("keys are not supported", func(t *testing.T) {
+		// --- When ---
+		err := EachKey(Required).Validate(maps.Keys(map[string]int{"a": 1}))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a map (ECInvType)", err)
+	})
+}
+
+func Test_EachKey(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"abc": 1, "def": 2}
+
+		// --- When ---
+		err := EachKey(Length(3, 3)).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid empty map", func(t *testing.T) {
+		// --- When ---
+		err := EachKey(Required).Validate(map[string]int(nil))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("values are not validated", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]ModelVal{"key0": {"def"}}
+
+		// --- When ---
+		err := EachKey(Required).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"abc": 1, "de": 2, "": 3}
+
+		// --- When ---
+		err := EachKey(Required, Length(3, 3)).Validate(val)
+
+		// --- Then ---
+		wMsg := "key:: cannot be blank (ECRequired); " +
+			"key:de: the length must be exactly 3 (ECInvLength)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("int keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[int]string{1: "a", 42: "b"}
+
+		// --- When ---
+		err := EachKey(Max(10)).Validate(val)
+
+		// --- Then ---
+		wMsg := "key:42: must be no greater than 10 (ECInvThreshold)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("not a map", func(t *testing.T) {
+		// --- When ---
+		err := EachKey(Required).Validate([]string{"abc"})
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a map (ECInvType)", err)
+	})
+}
+
+func Test_EachEntry(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"abc": "x", "def": "y"}
+
+		// --- When ---
+		err := EachEntry([]Rule{Length(3, 3)}, []Rule{Required}).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("key and value errors are distinguishable", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"abc": "", "de": "y", "x": ""}
+		rule := EachEntry([]Rule{Length(2, 3)}, []Rule{Required})
+
+		// --- When ---
+		err := rule.Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertFieldCnt(t, 3, err)
+		xrrtest.AssertFieldCode(t, "abc", ECRequired, err)
+		xrrtest.AssertFieldCode(t, "key:x", ECInvLength, err)
+		xrrtest.AssertFieldCode(t, "x", ECRequired, err)
+	})
+
+	t.Run("values implementing Validator", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]ModelVal{"key0": {"abc"}, "key1": {"def"}}
+
+		// --- When ---
+		err := EachEntry([]Rule{Required}, nil).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "key1.FStr: must be 'abc' (ECMustAbc)", err)
+	})
+
+	t.Run("not a map", func(t *testing.T) {
+		// --- When ---
+		err := EachEntry(nil, nil).Validate("abc")
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a map (ECInvType)", err)
+	})
+}
+
+func Test_EachRule_Parallel(t *testing.T) {
+	isEven := By(func(v any) error {
+		if v.(int)%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+
+	t.Run("same as sequential", func(t *testing.T) {
+		// --- Given ---
+		val := make([]int, 1000)
+		for i := range val {
+			val[i] = i
+		}
+
+		// --- When ---
+		want := Each(isEven).Validate(val)
+		have := Each(isEven).Parallel(8).Validate(val)
+
+		// --- Then ---
+		assert.Equal(t, want, have)
+		xrrtest.AssertFieldCnt(t, 500, have)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		// --- Given ---
+		val := []int{0, 2, 4}
+
+		// --- When ---
+		err := Each(isEven).Parallel(2).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"a": 1, "b": 2, "c": 3}
+
+		// --- When ---
+		err := Each(isEven).Parallel(2).Validate(val)
+
+		// --- Then ---
+		wMsg := "a: must be even (ECGeneric); c: must be even (ECGeneric)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("map entries", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"A": 1, "b": 2}
+		keyRules := []Rule{Match(regexp.MustCompile("^[a-z]$"))}
+		rule := EachEntry(keyRules, []Rule{isEven})
+
+		// --- When ---
+		err := rule.Parallel(2).Validate(val)
+
+		// --- Then ---
+		wMsg := "A: must be even (ECGeneric); " +
+			"key:A: must be in a valid format (ECInvMatch)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("iterator", func(t *testing.T) {
+		// --- Given ---
+		val := slices.Values([]int{1, 2, 3})
+
+		// --- When ---
+		err := Each(isEven).Parallel(2).Validate(val)
+
+		// --- Then ---
+		wMsg := "0: must be even (ECGeneric); 2: must be even (ECGeneric)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("not iterable", func(t *testing.T) {
+		// --- When ---
+		err := Each(isEven).Parallel(2).Validate(1)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be an iterable (ECInvType)", err)
+	})
+}
+
+func Test_EachRule_FailFast(t *testing.T) {
+	isEven := By(func(v any) error {
+		if v.(int)%2 != 0 {
+			return errors.New("must be even")
+		}
+		return nil
+	})
+
+	val := make([]int, 1000)
+	for i := range val {
+		val[i] = i
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		// --- When ---
+		err := Each(isEven).FailFast(2).Validate(val)
+
+		// --- Then ---
+		wMsg := "1: must be even (ECGeneric); 3: must be even (ECGeneric)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("parallel", func(t *testing.T) {
+		for range 20 {
+			// --- When ---
+			err := Each(isEven).Parallel(8).FailFast(2).Validate(val)
+
+			// --- Then ---
+			wMsg := "1: must be even (ECGeneric); 3: must be even (ECGeneric)"
+			xrrtest.AssertEqual(t, wMsg, err)
+		}
+	})
+
+	t.Run("stops consuming iterator", func(t *testing.T) {
+		// --- Given ---
+		var yielded int
+		seq := func(yield func(int) bool) {
+			for _, v := range val {
+				yielded++
+				if !yield(v) {
+					return
+				}
+			}
+		}
+
+		// --- When ---
+		err := Each(isEven).FailFast(1).Validate(iter.Seq[int](seq))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "1: must be even (ECGeneric)", err)
+		assert.Equal(t, 2, yielded)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"a": 1, "b": 2, "c": 3, "d": 5}
+
+		// --- When ---
+		have0 := Each(isEven).FailFast(2).Validate(val)
+		have1 := Each(isEven).Parallel(3).FailFast(2).Validate(val)
+
+		// --- Then ---
+		wMsg := "a: must be even (ECGeneric); c: must be even (ECGeneric)"
+		xrrtest.AssertEqual(t, wMsg, have0)
+		xrrtest.AssertEqual(t, wMsg, have1)
+	})
+
+	t.Run("limit not reached", func(t *testing.T) {
+		// --- When ---
+		err := Each(isEven).FailFast(10).Validate([]int{1, 2, 3})
+
+		// --- Then ---
+		wMsg := "0: must be even (ECGeneric); 2: must be even (ECGeneric)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+}
+
+func Test_EachRule_Parallel_race(t *testing.T) {
+	// --- Given ---
+	rule := Each(Required, Match(regexp.MustCompile(`^\w+@\w+$`))).
+		Parallel(4).
+		FailFast(10)
+
+	val := make([]string, 1000)
+	for i := range val {
+		val[i] = "user@example"
+		if i%7 == 0 {
+			val[i] = "invalid"
+		}
+	}
+	want := Each(Required, Match(regexp.MustCompile(`^\w+@\w+$`))).
+		FailFast(10).
+		Validate(val)
+
+	// --- When ---
+	var wg sync.WaitGroup
+	errs := make([]error, 8)
+	for i := range errs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = rule.Validate(val)
+		}()
+	}
+	wg.Wait()
+
+	// --- Then ---
+	for _, err := range errs {
+		assert.Equal(t, want, err)
+	}
+	xrrtest.AssertFieldCnt(t, 10, want)
+}
+
+func BenchmarkEachRule_Validate(b *testing.B) {
+	rule := Each(Required, Match(regexp.MustCompile(`^[a-z]+@[a-z]+\.com$`)))
+	val := make([]string, 10_000)
+	for i := range val {
+		val[i] = "user@example.com"
+	}
+
+	b.Run("sequential", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_ = rule.Validate(val)
+		}
+	})
+
+	b.Run("parallel", func(b *testing.B) {
+		b.ReportAllocs()
+		rule := rule.Parallel(runtime.GOMAXPROCS(0))
+		for i := 0; i < b.N; i++ {
+			_ = rule.Validate(val)
+		}
+	})
+}
@@ -4,8 +4,12 @@
 package verax
 
 import (
+	"bytes"
 	"fmt"
 	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
 
 	"github.com/ctx42/xrr/pkg/xrr"
 )
@@ -16,6 +20,33 @@ const ECMapKeyMissing = "ECMapKeyMissing"
 // ECMapKeyUnexpected represents error code for the not expected key.
 const ECMapKeyUnexpected = "ECMapKeyUnexpected"
 
+// ECMapKeyCount represents error code for the invalid number of keys.
+const ECMapKeyCount = "ECMapKeyCount"
+
+// ECMapKeyConflict represents error code for mutually exclusive keys.
+const ECMapKeyConflict = "ECMapKeyConflict"
+
+// MapKeyTrigger is the name of the error metadata key holding the name of the
+// key which triggered the key dependency error.
+const MapKeyTrigger = "trigger"
+
+// MapRule error message templates.
+var (
+	// tplMapMinKeys is the error message template for too few keys.
+	tplMapMinKeys = emtpl("must have at least {{.n}} keys")
+
+	// tplMapMaxKeys is the error message template for too many keys.
+	tplMapMaxKeys = emtpl("must have no more than {{.n}} keys")
+
+	// tplMapKeyRequired is the error message template for a key required by
+	// the presence of another key.
+	tplMapKeyRequired = emtpl("required when key {{.key}} is present")
+
+	// tplMapKeyConflict is the error message template for a key which must
+	// not be present together with another key.
+	tplMapKeyConflict = emtpl("must not be present together with key {{.key}}")
+)
+
 // MapRule sentinel errors.
 var (
 	// ErrNotMapPtr is the error that the value being validated is not a map.
@@ -34,12 +65,29 @@ var (
 // MapRule represents a rule set associated with a map.
 type MapRule struct {
 	keys         map[any]*KeyRules
+	patterns     []*KeyRules
+	paths        []*KeyRules
 	allowUnknown bool
+	additional   bool
+	addRules     []Rule
+	minKeys      int
+	maxKeys      int
+	deps         []keyDep
+}
+
+// keyDep represents a dependency between map keys.
+type keyDep struct {
+	key       any   // The triggering key, nil for exclusive keys.
+	keys      []any // Dependent keys.
+	exclusive bool  // Keys are mutually exclusive.
 }
 
 // KeyRules represents a rule set associated with a map key.
 type KeyRules struct {
 	key      any
+	match    func(name string) bool
+	path     []pathSeg
+	isPath   bool
 	optional bool
 	rules    []Rule
 }
@@ -59,12 +107,23 @@ type KeyRules struct {
 //
 // A nil value is considered valid. Use the Required rule to make sure a map
 // value is present.
+//
+// Use KeyPattern() or KeyPrefix() to specify rules for all the keys matching
+// a pattern, and Path() to specify rules for the nested values.
 func Map(keys ...*KeyRules) MapRule {
+	var pts, pls []*KeyRules
 	kr := make(map[any]*KeyRules, len(keys))
 	for _, k := range keys {
-		kr[k.key] = k
+		switch {
+		case k.match != nil:
+			pts = append(pts, k)
+		case k.isPath:
+			pls = append(pls, k)
+		default:
+			kr[k.key] = k
+		}
 	}
-	return MapRule{keys: kr}
+	return MapRule{keys: kr, patterns: pts, paths: pls}
 }
 
 // AllowUnknown configures the rule to ignore unknown keys.
@@ -73,6 +132,52 @@ func (r MapRule) AllowUnknown() MapRule {
 	return r
 }
 
+// Additional configures the rule to validate all the keys not declared with
+// Key(), KeyPattern() or KeyPrefix() using the given rules. The additional
+// keys are not considered unknown.
+func (r MapRule) Additional(rules ...Rule) MapRule {
+	r.additional = true
+	r.addRules = rules
+	return r
+}
+
+// MinKeys configures the minimum number of keys the map must have. When the
+// map has fewer keys, the keys are not validated.
+func (r MapRule) MinKeys(n int) MapRule {
+	r.minKeys = n
+	return r
+}
+
+// MaxKeys configures the maximum number of keys the map may have. When the
+// map has more keys, the keys are not validated.
+func (r MapRule) MaxKeys(n int) MapRule {
+	r.maxKeys = n
+	return r
+}
+
+// Requires configures the rule to require all the given keys when the key is
+// present in the map. Each missing key is reported with an error naming the
+// triggering key, which is also set as the [MapKeyTrigger] error metadata.
+//
+// Example:
+//
+//	// When "tls" is present, "cert" and "key" are required.
+//	rule := Map(...).Requires("tls", "cert", "key")
+func (r MapRule) Requires(key any, keys ...any) MapRule {
+	r.deps = append(r.deps, keyDep{key: key, keys: keys})
+	return r
+}
+
+// Exclusive configures the rule to allow at most one of the given keys in the
+// map. When more than one key is present, the first present key (in the
+// order given) is the triggering key, and all the other present keys are
+// reported with an error naming it. The triggering key is also set as the
+// [MapKeyTrigger] error metadata.
+func (r MapRule) Exclusive(keys ...any) MapRule {
+	r.deps = append(r.deps, keyDep{keys: keys, exclusive: true})
+	return r
+}
+
 // IsOptional returns true if the given map key is optional. It will return
 // true for keys that are not defined in the map.
 func (r MapRule) IsOptional(key any) bool {
@@ -82,10 +187,35 @@ func (r MapRule) IsOptional(key any) bool {
 	return true
 }
 
-// IsDefined returns true if the given map key is defined.
+// IsDefined returns true if the given map key is defined, matches one of the
+// key patterns, or is the first key of one of the paths.
 func (r MapRule) IsDefined(key any) bool {
-	_, ok := r.keys[key]
-	return ok
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+	return r.matches(key) || r.isPathRoot(key)
+}
+
+// matches returns true if the key matches any of the key patterns.
+func (r MapRule) matches(key any) bool {
+	name := getErrorKeyName(key)
+	for _, kr := range r.patterns {
+		if kr.match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPathRoot returns true if the key is the first key of any of the paths.
+func (r MapRule) isPathRoot(key any) bool {
+	name := getErrorKeyName(key)
+	for _, kr := range r.paths {
+		if len(kr.path) > 0 && kr.path[0].key == name {
+			return true
+		}
+	}
+	return false
 }
 
 // Validate checks if the given value is valid or not.
@@ -106,11 +236,16 @@ func (r MapRule) Validate(v any) error {
 		return nil
 	}
 
+	if err := r.checkKeyCount(val.Len()); err != nil {
+		return err
+	}
+
 	var ers xrr.Fields
 	kt := val.Type().Key()
 
+	trackExtra := !r.allowUnknown || r.additional
 	var extraKeys map[any]bool
-	if !r.allowUnknown {
+	if trackExtra {
 		extraKeys = make(map[any]bool, val.Len())
 		iter := val.MapRange()
 		for iter.Next() {
@@ -140,18 +275,75 @@ func (r MapRule) Validate(v any) error {
 			}
 			ers[getErrorKeyName(kr.key)] = err
 		}
-		if !r.allowUnknown {
+		if trackExtra {
 			delete(extraKeys, kr.key)
 		}
 	}
 
-	if !r.allowUnknown {
+	if len(r.patterns) > 0 {
+		iter := val.MapRange()
+		for iter.Next() {
+			key := iter.Key().Interface()
+			name := getErrorKeyName(key)
+			for _, kr := range r.patterns {
+				if !kr.match(name) {
+					continue
+				}
+				if trackExtra {
+					delete(extraKeys, key)
+				}
+				if _, ok := ers[name]; ok {
+					continue // Report only the first error for the key.
+				}
+				err := Validate(iter.Value().Interface(), kr.rules...)
+				if err == nil {
+					continue
+				}
+				if xrr.GetCode(err) == ECInternal {
+					msg := fmt.Sprintf("%s: %s", name, err)
+					return xrr.New(msg, ECInternal)
+				}
+				if ers == nil {
+					ers = xrr.Fields{}
+				}
+				ers[name] = err
+			}
+		}
+	}
+
+	for _, kr := range r.paths {
+		if err := validatePath(val, kr, &ers); err != nil {
+			return err
+		}
+		if trackExtra && len(kr.path) > 0 {
+			for key := range extraKeys {
+				if getErrorKeyName(key) == kr.path[0].key {
+					delete(extraKeys, key)
+				}
+			}
+		}
+	}
+
+	if err := r.checkDeps(val, &ers); err != nil {
+		return err
+	}
+
+	for key := range extraKeys {
+		err := ErrKeyUnexpected
+		if r.additional {
+			vv := val.MapIndex(reflect.ValueOf(key))
+			if err = Validate(vv.Interface(), r.addRules...); err == nil {
+				continue
+			}
+			if xrr.GetCode(err) == ECInternal {
+				msg := fmt.Sprintf("%s: %s", getErrorKeyName(key), err)
+				return xrr.New(msg, ECInternal)
+			}
+		}
 		if ers == nil {
 			ers = xrr.Fields{}
 		}
-		for key := range extraKeys {
-			ers[getErrorKeyName(key)] = ErrKeyUnexpected
-		}
+		ers[getErrorKeyName(key)] = err
 	}
 
 	if len(ers) > 0 {
@@ -160,6 +352,89 @@ func (r MapRule) Validate(v any) error {
 	return nil
 }
 
+// checkKeyCount returns an error if the number of keys is not within
+// configured limits.
+func (r MapRule) checkKeyCount(cnt int) error {
+	var tpl *template.Template
+	var n int
+	switch {
+	case r.minKeys > 0 && cnt < r.minKeys:
+		tpl, n = tplMapMinKeys, r.minKeys
+	case r.maxKeys > 0 && cnt > r.maxKeys:
+		tpl, n = tplMapMaxKeys, r.maxKeys
+	default:
+		return nil
+	}
+	buf := bytes.Buffer{}
+	_ = tpl.Execute(&buf, map[string]any{"n": n})
+	return xrr.New(buf.String(), ECMapKeyCount)
+}
+
+// checkDeps checks the key dependencies against the map. Keys with already
+// reported errors are skipped. Returns error with ECInternal code on
+// unexpected errors.
+func (r MapRule) checkDeps(val reflect.Value, ers *xrr.Fields) error {
+	kt := val.Type().Key()
+	has := func(key any) (bool, error) {
+		kv := reflect.ValueOf(key)
+		if !kv.IsValid() || !kt.AssignableTo(kv.Type()) {
+			msg := fmt.Sprintf("%s: %s", getErrorKeyName(key), ErrInvKeyType)
+			return false, xrr.New(msg, ECInternal)
+		}
+		return val.MapIndex(kv).IsValid(), nil
+	}
+
+	report := func(key, trigger any, tpl *template.Template, code string) {
+		name := getErrorKeyName(key)
+		if _, ok := (*ers)[name]; ok {
+			return // Report only the first error for the key.
+		}
+		tName := getErrorKeyName(trigger)
+		buf := bytes.Buffer{}
+		_ = tpl.Execute(&buf, map[string]any{"key": tName})
+		meta := xrr.Meta().Str(MapKeyTrigger, tName).Option()
+		xrr.AddField(ers, name, xrr.New(buf.String(), code, meta))
+	}
+
+	for _, dep := range r.deps {
+		if dep.exclusive {
+			var trigger any
+			for _, key := range dep.keys {
+				ok, err := has(key)
+				if err != nil {
+					return err
+				}
+				switch {
+				case !ok:
+				case trigger == nil:
+					trigger = key
+				default:
+					report(key, trigger, tplMapKeyConflict, ECMapKeyConflict)
+				}
+			}
+			continue
+		}
+
+		ok, err := has(dep.key)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		for _, key := range dep.keys {
+			ok, err := has(key)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				report(key, dep.key, tplMapKeyRequired, ECMapKeyMissing)
+			}
+		}
+	}
+	return nil
+}
+
 // Key specifies a map key and the corresponding validation rules.
 func Key(key any, rules ...Rule) *KeyRules {
 	return &KeyRules{
@@ -168,6 +443,32 @@ func Key(key any, rules ...Rule) *KeyRules {
 	}
 }
 
+// KeyPattern specifies the rules for all the map keys matching the regular
+// expression. Keys are matched using their string representation. The keys
+// matching the pattern are not considered unknown. Missing keys are never
+// reported for pattern rules.
+func KeyPattern(re *regexp.Regexp, rules ...Rule) *KeyRules {
+	return &KeyRules{
+		key:      re.String(),
+		match:    re.MatchString,
+		optional: true,
+		rules:    rules,
+	}
+}
+
+// KeyPrefix specifies the rules for all the map keys starting with the
+// prefix. Keys are matched using their string representation. The keys
+// starting with the prefix are not considered unknown. Missing keys are never
+// reported for prefix rules.
+func KeyPrefix(prefix string, rules ...Rule) *KeyRules {
+	return &KeyRules{
+		key:      prefix,
+		match:    func(name string) bool { return strings.HasPrefix(name, prefix) },
+		optional: true,
+		rules:    rules,
+	}
+}
+
 // Optional configures the rule to ignore the key if missing.
 func (r *KeyRules) Optional() *KeyRules {
 	r.optional = true
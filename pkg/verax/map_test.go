@@ -4,9 +4,11 @@
 package verax
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr"
 	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
 )
 
@@ -364,6 +366,382 @@ func Test_MapRule_invalid(t *testing.T) {
 	})
 }
 
+func Test_MapRule_patterns(t *testing.T) {
+	t.Run("valid pattern keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"app.io/name": "abc", "app.io/tier": "xyz"}
+		rx := regexp.MustCompile(`^app\.io/`)
+
+		// --- When ---
+		err := Map(KeyPattern(rx, Length(3, 3))).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid prefix keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"x-a": "abc", "x-b": "xyz"}
+
+		// --- When ---
+		err := Map(KeyPrefix("x-", Length(3, 3))).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid no matching keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{}
+
+		// --- When ---
+		err := Map(KeyPrefix("x-", Required)).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid pattern keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"x-a": "abc", "x-b": "", "name": "n"}
+		rs := []*KeyRules{
+			Key("name", Required),
+			KeyPrefix("x-", Required),
+		}
+
+		// --- When ---
+		err := Map(rs...).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "x-b: cannot be blank (ECRequired)", err)
+	})
+
+	t.Run("unmatched keys are unexpected", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"x-a": "abc", "y-b": "xyz"}
+
+		// --- When ---
+		err := Map(KeyPrefix("x-")).Validate(val)
+
+		// --- Then ---
+		wMsg := "y-b: key not expected (ECMapKeyUnexpected)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("explicit key rules are applied first", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"x-a": "", "x-b": "ab"}
+		rs := []*KeyRules{
+			Key("x-a", Required),
+			KeyPrefix("x-", Length(3, 3)),
+		}
+
+		// --- When ---
+		err := Map(rs...).Validate(val)
+
+		// --- Then ---
+		wMsg := "x-a: cannot be blank (ECRequired); " +
+			"x-b: the length must be exactly 3 (ECInvLength)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("multiple patterns", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"x-a": "abcd"}
+		rs := []*KeyRules{
+			KeyPrefix("x-", Length(1, 5)),
+			KeyPattern(regexp.MustCompile(`a$`), Length(1, 3)),
+		}
+
+		// --- When ---
+		err := Map(rs...).Validate(val)
+
+		// --- Then ---
+		wMsg := "x-a: the length must be between 1 and 3 (ECInvLength)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("int keys", func(t *testing.T) {
+		// --- Given ---
+		rs := []*KeyRules{
+			KeyPattern(regexp.MustCompile(`^\d$`), StrRule("abc")),
+		}
+
+		// --- When ---
+		err := Map(rs...).Validate(TMapInt)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "3: must be 'abc' (ECMustAbc)", err)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"x-a": "abc"}
+
+		// --- When ---
+		err := Map(KeyPrefix("x-", InternalErrRule)).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "x-a: internal error (ECInternal)", err)
+	})
+}
+
+func Test_MapRule_Additional(t *testing.T) {
+	t.Run("valid additional keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"name": "n", "a": "abc", "b": "xyz"}
+
+		// --- When ---
+		err := Map(Key("name")).Additional(Length(3, 3)).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid additional keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"name": "n", "a": "abc", "b": "xy"}
+
+		// --- When ---
+		err := Map(Key("name")).Additional(Length(3, 3)).Validate(val)
+
+		// --- Then ---
+		wMsg := "b: the length must be exactly 3 (ECInvLength)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("declared keys are not additional", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"name": "n", "x-a": "a", "b": "xy"}
+		rs := []*KeyRules{
+			Key("name"),
+			KeyPrefix("x-"),
+		}
+
+		// --- When ---
+		err := Map(rs...).Additional(Length(3, 3)).Validate(val)
+
+		// --- Then ---
+		wMsg := "b: the length must be exactly 3 (ECInvLength)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("no rules validates Validator values", func(t *testing.T) {
+		// --- When ---
+		err := Map(Key("KStrAbc")).Additional().Validate(TMap)
+
+		// --- Then ---
+		wMsg := "KStructInvalid.FStr: must be 'abc' (ECMustAbc)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("int keys", func(t *testing.T) {
+		// --- When ---
+		err := Map(Key(1)).Additional(StrRule("abc")).Validate(TMapInt)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "3: must be 'abc' (ECMustAbc)", err)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]string{"a": "abc"}
+
+		// --- When ---
+		err := Map().Additional(InternalErrRule).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "a: internal error (ECInternal)", err)
+	})
+}
+
+func Test_MapRule_MinKeys_MaxKeys(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"a": 1, "b": 2}
+
+		// --- When ---
+		err := Map().AllowUnknown().MinKeys(2).MaxKeys(2).Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("too few keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"a": 1}
+
+		// --- When ---
+		err := Map().AllowUnknown().MinKeys(2).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must have at least 2 keys (ECMapKeyCount)", err)
+	})
+
+	t.Run("too many keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"a": 1, "b": 2, "c": 3}
+
+		// --- When ---
+		err := Map().AllowUnknown().MaxKeys(2).Validate(val)
+
+		// --- Then ---
+		wMsg := "must have no more than 2 keys (ECMapKeyCount)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("nil map is valid", func(t *testing.T) {
+		// --- When ---
+		err := Map().MinKeys(2).Validate(dMap)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+}
+
+func Test_MapRule_Requires(t *testing.T) {
+	t.Run("trigger present and dependencies present", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"tls": true, "cert": "c", "key": "k"}
+
+		// --- When ---
+		err := Map().AllowUnknown().Requires("tls", "cert", "key").Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("trigger not present", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"host": "localhost"}
+
+		// --- When ---
+		err := Map().AllowUnknown().Requires("tls", "cert", "key").Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("missing dependencies", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"tls": true, "cert": "c"}
+		rule := Map(
+			Key("tls", Required),
+			Key("cert", Required).Optional(),
+			Key("key", Required).Optional(),
+		).Requires("tls", "cert", "key")
+
+		// --- When ---
+		err := rule.Validate(val)
+
+		// --- Then ---
+		wMsg := "key: required when key tls is present (ECMapKeyMissing)"
+		xrrtest.AssertEqual(t, wMsg, err)
+		xrrtest.AssertFieldCode(t, "key", ECMapKeyMissing, err)
+		fErr := xrr.GetFields(err)["key"]
+		xrrtest.AssertStr(t, MapKeyTrigger, "tls", fErr)
+	})
+
+	t.Run("first error for the key is reported", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"tls": true}
+		rule := Map(Key("tls"), Key("cert")).Requires("tls", "cert")
+
+		// --- When ---
+		err := rule.Validate(val)
+
+		// --- Then ---
+		wMsg := "cert: required key is missing (ECMapKeyMissing)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("invalid key type", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"tls": true}
+
+		// --- When ---
+		err := Map().AllowUnknown().Requires("tls", 1).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "1: key not the correct type (ECInternal)", err)
+	})
+
+	t.Run("trigger not present checks next dependencies", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"a": 1, "b": 2, "tls": true}
+		rule := Map().
+			AllowUnknown().
+			Requires("host", "port").
+			Requires("tls", "cert").
+			Exclusive("a", "b")
+
+		// --- When ---
+		err := rule.Validate(val)
+
+		// --- Then ---
+		wMsg := "b: must not be present together with key a " +
+			"(ECMapKeyConflict); " +
+			"cert: required when key tls is present (ECMapKeyMissing)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+}
+
+func Test_MapRule_Exclusive(t *testing.T) {
+	t.Run("one key present", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"a": 1, "c": 3}
+
+		// --- When ---
+		err := Map().AllowUnknown().Exclusive("a", "b").Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("no keys present", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"c": 3}
+
+		// --- When ---
+		err := Map().AllowUnknown().Exclusive("a", "b").Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("conflicting keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"b": 2, "c": 3, "d": 4}
+
+		// --- When ---
+		err := Map().
+			AllowUnknown().
+			Exclusive("a", "b", "c", "d").
+			Validate(val)
+
+		// --- Then ---
+		wMsg := "c: must not be present together with key b (ECMapKeyConflict); " +
+			"d: must not be present together with key b (ECMapKeyConflict)"
+		xrrtest.AssertEqual(t, wMsg, err)
+		fErr := xrr.GetFields(err)["d"]
+		xrrtest.AssertStr(t, MapKeyTrigger, "b", fErr)
+	})
+
+	t.Run("with other errors", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"a": "", "b": 2}
+		rule := Map(Key("a", Required), Key("b")).Exclusive("a", "b")
+
+		// --- When ---
+		err := rule.Validate(val)
+
+		// --- Then ---
+		wMsg := "a: cannot be blank (ECRequired); " +
+			"b: must not be present together with key a (ECMapKeyConflict)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+}
+
 func Test_MapRule_IsOptional(t *testing.T) {
 	// --- Given ---
 	rs := []*KeyRules{
@@ -395,6 +773,23 @@ func Test_MapRule_IsDefined(t *testing.T) {
 	assert.False(t, mr.IsDefined(nil))
 }
 
+func Test_MapRule_IsDefined_patterns(t *testing.T) {
+	// --- Given ---
+	rs := []*KeyRules{
+		Key("name"),
+		KeyPrefix("x-"),
+		KeyPattern(regexp.MustCompile(`^\d+$`)),
+	}
+	mr := Map(rs...)
+
+	// --- Then ---
+	assert.True(t, mr.IsDefined("name"))
+	assert.True(t, mr.IsDefined("x-abc"))
+	assert.True(t, mr.IsDefined(123))
+	assert.False(t, mr.IsDefined("abc"))
+	assert.True(t, mr.IsOptional("x-abc"))
+}
+
 func Test_KeyRules(t *testing.T) {
 	t.Run("simple", func(t *testing.T) {
 		// --- When ---
@@ -429,4 +824,28 @@ func Test_KeyRules(t *testing.T) {
 		// --- Then ---
 		assert.True(t, kr.optional)
 	})
+
+	t.Run("pattern", func(t *testing.T) {
+		// --- When ---
+		kr := KeyPattern(regexp.MustCompile(`^a`), Noop)
+
+		// --- Then ---
+		assert.Equal(t, "^a", kr.key)
+		assert.True(t, kr.optional)
+		assert.True(t, kr.match("abc"))
+		assert.False(t, kr.match("bcd"))
+		assert.Len(t, 1, kr.rules)
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		// --- When ---
+		kr := KeyPrefix("x-", Noop)
+
+		// --- Then ---
+		assert.Equal(t, "x-", kr.key)
+		assert.True(t, kr.optional)
+		assert.True(t, kr.match("x-abc"))
+		assert.False(t, kr.match("abc"))
+		assert.Len(t, 1, kr.rules)
+	})
 }
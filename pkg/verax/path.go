@@ -0,0 +1,195 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+)
+
+// ErrInvPath is the error returned in case of an invalid path syntax.
+var ErrInvPath = xrr.New("invalid path", ECInternal)
+
+// Path specifies the rules for a value nested in a map at the given path.
+// The path is a dot separated list of map keys where each key may be followed
+// by one or more slice (or array) indexes in square brackets. Use "*" as the
+// index to validate all the slice elements.
+//
+// Example paths:
+//
+//	"a.b[0].c"     // Key "c" of the first element of slice "b" in map "a".
+//	"items[*].sku" // Key "sku" of all the elements of slice "items".
+//
+// Missing nodes on the path are reported the same way as missing keys (see
+// [ErrKeyMissing]) unless the rules are marked as optional. Errors are
+// reported under the full path with wildcards replaced by indexes. The first
+// key of the path is not considered unknown.
+func Path(path string, rules ...Rule) *KeyRules {
+	segs, _ := parsePath(path) // Invalid paths are reported by MapRule.
+	return &KeyRules{
+		key:    path,
+		path:   segs,
+		isPath: true,
+		rules:  rules,
+	}
+}
+
+// pathSeg represents a single path segment.
+type pathSeg struct {
+	key      string // Map key.
+	index    int    // Slice index.
+	isIndex  bool   // Segment is an index.
+	wildcard bool   // Segment is a wildcard index.
+}
+
+// String returns path segment string representation.
+func (seg pathSeg) String() string {
+	switch {
+	case seg.wildcard:
+		return "[*]"
+	case seg.isIndex:
+		return "[" + strconv.Itoa(seg.index) + "]"
+	default:
+		return seg.key
+	}
+}
+
+// parsePath parses path into its segments.
+func parsePath(path string) ([]pathSeg, error) {
+	var segs []pathSeg
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" || strings.Contains(key, "]") {
+			return nil, ErrInvPath
+		}
+		segs = append(segs, pathSeg{key: key})
+		if rest == "" && !strings.HasSuffix(part, "[") {
+			continue
+		}
+		for _, idx := range strings.Split(rest, "[") {
+			idx, ok := strings.CutSuffix(idx, "]")
+			if !ok {
+				return nil, ErrInvPath
+			}
+			if idx == "*" {
+				segs = append(segs, pathSeg{isIndex: true, wildcard: true})
+				continue
+			}
+			i, err := strconv.Atoi(idx)
+			if err != nil || i < 0 {
+				return nil, ErrInvPath
+			}
+			segs = append(segs, pathSeg{index: i, isIndex: true})
+		}
+	}
+	return segs, nil
+}
+
+// walkPath walks the value v along the path segments and calls fn with the
+// name (path) and the value of each node at the end of the path. When the
+// node does not exist, fn is called with found set to false.
+func walkPath(
+	v reflect.Value,
+	segs []pathSeg,
+	name string,
+	fn func(name string, v reflect.Value, found bool),
+) {
+
+	if len(segs) == 0 {
+		fn(name, v, true)
+		return
+	}
+	for v.IsValid() &&
+		(v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+
+		v = v.Elem()
+	}
+
+	seg := segs[0]
+	if !seg.isIndex {
+		if !v.IsValid() || v.Kind() != reflect.Map ||
+			v.Type().Key().Kind() != reflect.String {
+
+			fn(joinPath(name, segs...), reflect.Value{}, false)
+			return
+		}
+		kv := reflect.ValueOf(seg.key).Convert(v.Type().Key())
+		mv := v.MapIndex(kv)
+		if !mv.IsValid() {
+			fn(joinPath(name, segs...), reflect.Value{}, false)
+			return
+		}
+		walkPath(mv, segs[1:], joinPath(name, seg), fn)
+		return
+	}
+
+	if !v.IsValid() ||
+		v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+
+		fn(joinPath(name, segs...), reflect.Value{}, false)
+		return
+	}
+	if seg.wildcard {
+		for i := 0; i < v.Len(); i++ {
+			idx := pathSeg{index: i, isIndex: true}
+			walkPath(v.Index(i), segs[1:], joinPath(name, idx), fn)
+		}
+		return
+	}
+	if seg.index >= v.Len() {
+		fn(joinPath(name, segs...), reflect.Value{}, false)
+		return
+	}
+	walkPath(v.Index(seg.index), segs[1:], joinPath(name, seg), fn)
+}
+
+// joinPath appends path segments to the path.
+func joinPath(path string, segs ...pathSeg) string {
+	var buf strings.Builder
+	buf.WriteString(path)
+	for _, seg := range segs {
+		if !seg.isIndex && buf.Len() > 0 {
+			buf.WriteString(".")
+		}
+		buf.WriteString(seg.String())
+	}
+	return buf.String()
+}
+
+// validatePath validates the map value using path rules. Returns error with
+// ECInternal code on unexpected errors.
+func validatePath(val reflect.Value, kr *KeyRules, ers *xrr.Fields) error {
+	if kr.path == nil {
+		return xrr.New(fmt.Sprintf("%v: %s", kr.key, ErrInvPath), ECInternal)
+	}
+
+	var ie error
+	fn := func(name string, v reflect.Value, found bool) {
+		if ie != nil {
+			return
+		}
+		var err error
+		if !found {
+			if !kr.optional {
+				err = ErrKeyMissing
+			}
+		} else {
+			err = Validate(getInterface(v), kr.rules...)
+		}
+		if err == nil {
+			return
+		}
+		if xrr.GetCode(err) == ECInternal {
+			ie = xrr.New(fmt.Sprintf("%s: %s", name, err), ECInternal)
+			return
+		}
+		xrr.AddField(ers, name, err)
+	}
+	walkPath(val, kr.path, "", fn)
+	return ie
+}
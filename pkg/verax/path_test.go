@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/testing/pkg/must"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+)
+
+func Test_Path(t *testing.T) {
+	t.Run("valid path", func(t *testing.T) {
+		// --- When ---
+		kr := Path("a.b[0]", Noop)
+
+		// --- Then ---
+		assert.Equal(t, "a.b[0]", kr.key)
+		assert.True(t, kr.isPath)
+		assert.Len(t, 3, kr.path)
+		assert.False(t, kr.optional)
+		assert.Len(t, 1, kr.rules)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		// --- When ---
+		kr := Path("a..b", Noop)
+
+		// --- Then ---
+		assert.True(t, kr.isPath)
+		assert.Nil(t, kr.path)
+	})
+}
+
+func Test_parsePath_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		path string
+		want []pathSeg
+	}{
+		{"key", "a", []pathSeg{{key: "a"}}},
+		{"keys", "a.b", []pathSeg{{key: "a"}, {key: "b"}}},
+		{
+			"index",
+			"a[1]",
+			[]pathSeg{{key: "a"}, {index: 1, isIndex: true}},
+		},
+		{
+			"wildcard",
+			"a[*].b",
+			[]pathSeg{
+				{key: "a"},
+				{isIndex: true, wildcard: true},
+				{key: "b"},
+			},
+		},
+		{
+			"multi index",
+			"a[0][2]",
+			[]pathSeg{
+				{key: "a"},
+				{index: 0, isIndex: true},
+				{index: 2, isIndex: true},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have, err := parsePath(tc.path)
+
+			// --- Then ---
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_parsePath_error_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		path string
+	}{
+		{"empty", ""},
+		{"empty key", "a..b"},
+		{"leading dot", ".a"},
+		{"index only", "[0]"},
+		{"not closed", "a[0"},
+		{"open bracket", "a["},
+		{"empty index", "a[]"},
+		{"negative index", "a[-1]"},
+		{"not a number", "a[x]"},
+		{"chars after index", "a[0]b"},
+		{"closing bracket in key", "a]"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have, err := parsePath(tc.path)
+
+			// --- Then ---
+			assert.ErrorIs(t, ErrInvPath, err)
+			assert.Nil(t, have)
+		})
+	}
+}
+
+func Test_walkPath(t *testing.T) {
+	t.Run("found", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"a": []any{map[string]any{"b": 1}}}
+		segs := must.Value(parsePath("a[0].b"))
+
+		var names []string
+		var values []any
+		fn := func(name string, v reflect.Value, found bool) {
+			assert.True(t, found)
+			names = append(names, name)
+			values = append(values, v.Interface())
+		}
+
+		// --- When ---
+		walkPath(reflect.ValueOf(val), segs, "", fn)
+
+		// --- Then ---
+		assert.Equal(t, []string{"a[0].b"}, names)
+		assert.Equal(t, []any{1}, values)
+	})
+
+	t.Run("wildcard", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"a": []int{1, 2}}
+		segs := must.Value(parsePath("a[*]"))
+
+		var names []string
+		fn := func(name string, v reflect.Value, found bool) {
+			assert.True(t, found)
+			names = append(names, name)
+		}
+
+		// --- When ---
+		walkPath(reflect.ValueOf(val), segs, "", fn)
+
+		// --- Then ---
+		assert.Equal(t, []string{"a[0]", "a[1]"}, names)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]any{"a": []any{}}
+		segs := must.Value(parsePath("a[*].b.c"))
+		segs2 := must.Value(parsePath("a[1].b.c"))
+
+		var names []string
+		fn := func(name string, v reflect.Value, found bool) {
+			assert.False(t, found)
+			names = append(names, name)
+		}
+
+		// --- When ---
+		walkPath(reflect.ValueOf(val), segs, "", fn)
+		walkPath(reflect.ValueOf(val), segs2, "", fn)
+
+		// --- Then ---
+		assert.Equal(t, []string{"a[1].b.c"}, names)
+	})
+}
+
+func Test_joinPath_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		path string
+		segs []pathSeg
+		want string
+	}{
+		{"empty", "", nil, ""},
+		{"key", "", []pathSeg{{key: "a"}}, "a"},
+		{"key to path", "a", []pathSeg{{key: "b"}}, "a.b"},
+		{"index", "a", []pathSeg{{index: 1, isIndex: true}}, "a[1]"},
+		{
+			"wildcard and key",
+			"a",
+			[]pathSeg{{isIndex: true, wildcard: true}, {key: "b"}},
+			"a[*].b",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := joinPath(tc.path, tc.segs...)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_MapRule_Path(t *testing.T) {
+	payload := `{
+		"event": "order.created",
+		"order": {
+			"id": "o-1",
+			"items": [
+				{"sku": "A-1", "qty": 1},
+				{"sku": "", "qty": 0},
+				{"qty": 2}
+			],
+			"customer": {"email": null}
+		}
+	}`
+	var data map[string]any
+	must.Nil(json.Unmarshal([]byte(payload), &data))
+
+	t.Run("valid", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(
+			Key("event", Required),
+			Path("order.id", Required),
+			Path("order.items[0].sku", Equal("A-1")),
+			Path("order.items[*].qty", Max(2.0)),
+		)
+
+		// --- When ---
+		err := rule.Validate(data)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("wildcard errors are reported at full path", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.items[*].sku", Required)).AllowUnknown()
+
+		// --- When ---
+		err := rule.Validate(data)
+
+		// --- Then ---
+		wMsg := "order.items[1].sku: cannot be blank (ECRequired); " +
+			"order.items[2].sku: required key is missing (ECMapKeyMissing)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("missing intermediate node", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.shipping.address", Required)).AllowUnknown()
+
+		// --- When ---
+		err := rule.Validate(data)
+
+		// --- Then ---
+		wMsg := "order.shipping.address: required key is missing " +
+			"(ECMapKeyMissing)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("null intermediate node", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.customer.email.x", Required)).AllowUnknown()
+
+		// --- When ---
+		err := rule.Validate(data)
+
+		// --- Then ---
+		wMsg := "order.customer.email.x: required key is missing " +
+			"(ECMapKeyMissing)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("optional missing node", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.shipping.address", Required).Optional())
+
+		// --- When ---
+		err := rule.AllowUnknown().Validate(data)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("path root is not unknown", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.id", Required))
+
+		// --- When ---
+		err := rule.Validate(data)
+
+		// --- Then ---
+		wMsg := "event: key not expected (ECMapKeyUnexpected)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("invalid path", func(t *testing.T) {
+		// --- When ---
+		err := Map(Path("order..id")).AllowUnknown().Validate(data)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "order..id: invalid path (ECInternal)", err)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.id", InternalErrRule)).AllowUnknown()
+
+		// --- When ---
+		err := rule.Validate(data)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "order.id: internal error (ECInternal)", err)
+	})
+
+	t.Run("is defined", func(t *testing.T) {
+		// --- Given ---
+		rule := Map(Path("order.id"))
+
+		// --- Then ---
+		assert.True(t, rule.IsDefined("order"))
+		assert.False(t, rule.IsDefined("id"))
+		assert.False(t, rule.IsDefined(1))
+	})
+
+	t.Run("named key type", func(t *testing.T) {
+		// --- Given ---
+		type tKey string
+		val := map[tKey]any{"order": map[string]any{"id": 1}}
+		rule := Map(Path("order.id", Required))
+
+		// --- When ---
+		err := rule.Validate(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.True(t, rule.IsDefined(tKey("order")))
+	})
+}
@@ -0,0 +1,284 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+)
+
+// ECInvCount represents error code for an invalid number of matching elements.
+const ECInvCount = "ECInvCount"
+
+// Quantifier rules error metadata keys.
+const (
+	// ContainsCount is the name of the error metadata key holding the number
+	// of matching elements or found values.
+	ContainsCount = "count"
+
+	// ContainsIndices is the name of the error metadata key holding comma
+	// separated list of indexes (or map keys) of the offending elements.
+	ContainsIndices = "indices"
+
+	// ContainsMissing is the name of the error metadata key holding comma
+	// separated list of missing values.
+	ContainsMissing = "missing"
+)
+
+// Message templates for quantifier rules.
+var (
+	// tplContainsAtLeast is an error message template for at least n.
+	tplContainsAtLeast = emtpl("must contain at least {{.n}} matching " +
+		"element{{if ne .n 1}}s{{end}}, found {{.count}}")
+
+	// tplContainsAtMost is an error message template for at most n.
+	tplContainsAtMost = emtpl("must contain no more than {{.n}} matching " +
+		"element{{if ne .n 1}}s{{end}}, found {{.count}}")
+
+	// tplContainsExactly is an error message template for exactly n.
+	tplContainsExactly = emtpl("must contain exactly {{.n}} matching " +
+		"element{{if ne .n 1}}s{{end}}, found {{.count}}")
+
+	// tplContainsNone is an error message template for none.
+	tplContainsNone = emtpl("must not contain matching elements, " +
+		"found {{.count}}")
+
+	// tplContainsAll is an error message template for all values.
+	tplContainsAll = emtpl("must contain all the values, missing {{.missing}}")
+)
+
+// Quantifiers.
+const (
+	quantAtLeast = iota // At least n elements must match.
+	quantAtMost         // At most n elements must match.
+	quantExactly        // Exactly n elements must match.
+	quantAll            // All values must be present.
+)
+
+// ContainsAtLeast returns a validation rule that checks if at least n elements
+// of an iterable (map, slice or array) pass all the given rules.
+//
+// Example:
+//
+//	// At least one primary address.
+//	rule := ContainsAtLeast(1, By(isPrimary))
+func ContainsAtLeast(n int, rules ...Rule) QuantifierRule {
+	return QuantifierRule{
+		quant:     quantAtLeast,
+		n:         n,
+		rules:     rules,
+		condition: true,
+		errTpl:    tplContainsAtLeast,
+		code:      ECInvCount,
+	}
+}
+
+// ContainsAtMost returns a validation rule that checks if at most n elements
+// of an iterable (map, slice or array) pass all the given rules. The indexes
+// (or map keys) of the matching elements are set as the [ContainsIndices]
+// error metadata.
+//
+// Example:
+//
+//	// No more than 3 admins.
+//	rule := ContainsAtMost(3, By(isAdmin))
+func ContainsAtMost(n int, rules ...Rule) QuantifierRule {
+	return QuantifierRule{
+		quant:     quantAtMost,
+		n:         n,
+		rules:     rules,
+		condition: true,
+		errTpl:    tplContainsAtMost,
+		code:      ECInvCount,
+	}
+}
+
+// ContainsExactly returns a validation rule that checks if exactly n elements
+// of an iterable (map, slice or array) pass all the given rules. When there
+// are too many matching elements, their indexes (or map keys) are set as the
+// [ContainsIndices] error metadata.
+//
+// Example:
+//
+//	// Exactly one primary address.
+//	rule := ContainsExactly(1, By(isPrimary))
+func ContainsExactly(n int, rules ...Rule) QuantifierRule {
+	return QuantifierRule{
+		quant:     quantExactly,
+		n:         n,
+		rules:     rules,
+		condition: true,
+		errTpl:    tplContainsExactly,
+		code:      ECInvCount,
+	}
+}
+
+// ContainsNone returns a validation rule that checks none of the elements of
+// an iterable (map, slice or array) pass all the given rules. The indexes (or
+// map keys) of the matching elements are set as the [ContainsIndices] error
+// metadata.
+func ContainsNone(rules ...Rule) QuantifierRule {
+	return QuantifierRule{
+		quant:     quantAtMost,
+		n:         0,
+		rules:     rules,
+		condition: true,
+		errTpl:    tplContainsNone,
+		code:      ECFound,
+	}
+}
+
+// ContainsAll returns a validation rule that checks if all the given values
+// are present in an iterable (map, slice or array). The values are compared
+// using [reflect.DeepEqual]. The missing values are set as the
+// [ContainsMissing] error metadata.
+func ContainsAll(values ...any) QuantifierRule {
+	return QuantifierRule{
+		quant:     quantAll,
+		values:    values,
+		condition: true,
+		errTpl:    tplContainsAll,
+		code:      ECNotFound,
+	}
+}
+
+// Compile time checks.
+var (
+	_ Customizer[QuantifierRule]  = QuantifierRule{}
+	_ Conditioner[QuantifierRule] = QuantifierRule{}
+)
+
+// QuantifierRule is a validation rule that checks the number of elements of
+// an iterable matching the rules or equal to the values.
+type QuantifierRule struct {
+	quant     int                // The quantifier.
+	n         int                // Number of matching elements.
+	rules     []Rule             // Rules elements must pass to match.
+	values    []any              // Values which must be present.
+	condition bool               // Run validation only when true.
+	errTpl    *template.Template // Error template.
+	err       error              // Custom error.
+	code      string             // Error code.
+}
+
+// Validate checks if the given value is valid or not. Element rule errors with
+// the [ECInternal] or [ECInvType] codes do not mean the element is not
+// matching, they are returned prefixed with the element name.
+func (r QuantifierRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	if r.quant == quantAll {
+		return r.validateAll(v)
+	}
+
+	var ie error
+	var matching []string
+	match := func(name string, val any) bool {
+		err := Validate(val, r.rules...)
+		if err == nil {
+			matching = append(matching, name)
+			return true
+		}
+		if code := xrr.GetCode(err); code == ECInternal || code == ECInvType {
+			ie = xrr.New(fmt.Sprintf("%s: %s", name, err), code)
+			return false
+		}
+		return true
+	}
+	if !iterate(v, match) {
+		return xrr.New("must be an iterable", ECInvType)
+	}
+	if ie != nil {
+		return ie
+	}
+
+	cnt := len(matching)
+	var failed bool
+	switch r.quant {
+	case quantAtLeast:
+		failed = cnt < r.n
+	case quantAtMost, quantExactly:
+		failed = cnt > r.n || r.quant == quantExactly && cnt < r.n
+	}
+	if !failed {
+		return nil
+	}
+
+	meta := xrr.Meta().Int(ContainsCount, cnt)
+	if cnt > r.n {
+		meta = meta.Str(ContainsIndices, strings.Join(matching, ","))
+	}
+	data := map[string]any{"n": r.n, "count": cnt}
+	return r.error(data, meta)
+}
+
+// validateAll checks if all the values are present in the iterable.
+func (r QuantifierRule) validateAll(v any) error {
+	found := make([]bool, len(r.values))
+	match := func(_ string, val any) bool {
+		for i, want := range r.values {
+			if !found[i] && reflect.DeepEqual(want, val) {
+				found[i] = true
+			}
+		}
+		return true
+	}
+	if !iterate(v, match) {
+		return xrr.New("must be an iterable", ECInvType)
+	}
+
+	var cnt int
+	var missing []string
+	for i, ok := range found {
+		if ok {
+			cnt++
+			continue
+		}
+		missing = append(missing, fmt.Sprintf("%v", format(r.values[i])))
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	lst := strings.Join(missing, ",")
+	meta := xrr.Meta().Int(ContainsCount, cnt).Str(ContainsMissing, lst)
+	return r.error(map[string]any{"missing": lst}, meta)
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r QuantifierRule) When(condition bool) QuantifierRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r QuantifierRule) Code(code string) QuantifierRule {
+	r.code = code
+	r.err = setCode(r.err, code)
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r QuantifierRule) Error(err error) QuantifierRule {
+	r.err = err
+	return r
+}
+
+// error constructs the rule error with the given template data and metadata.
+func (r QuantifierRule) error(data map[string]any, meta xrr.Metadata) error {
+	if r.err != nil {
+		return xrr.Wrap(r.err, meta.Option())
+	}
+	buf := bytes.Buffer{}
+	_ = r.errTpl.Execute(&buf, data)
+	return xrr.New(buf.String(), r.code, meta.Option())
+}
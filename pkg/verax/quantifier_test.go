@@ -0,0 +1,226 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+)
+
+func Test_QuantifierRule_Validate_valid_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		val  any
+		rule QuantifierRule
+	}{
+		{"at least zero", []int(nil), ContainsAtLeast(0, Min(2))},
+		{"at least", []int{1, 2, 3}, ContainsAtLeast(2, Min(2))},
+		{"at least more", []int{1, 2, 3}, ContainsAtLeast(1, Min(2))},
+		{"at most", []int{1, 2, 3}, ContainsAtMost(2, Min(2))},
+		{"at most less", []int{1, 2, 3}, ContainsAtMost(3, Min(2))},
+		{"exactly", []int{1, 2, 3}, ContainsExactly(2, Min(2))},
+		{"exactly zero", []int{}, ContainsExactly(0, Min(2))},
+		{"none", []int{1, 2, 3}, ContainsNone(Min(4))},
+		{"none empty", []int(nil), ContainsNone(Min(4))},
+		{"all", []string{"a", "b", "c"}, ContainsAll("c", "a")},
+		{"all no values", []string{"a"}, ContainsAll()},
+		{"map", map[string]int{"A": 1, "B": 2}, ContainsExactly(1, Min(2))},
+		{"array", [...]int{1, 2}, ContainsAll(2)},
+		{"condition false", []int{1}, ContainsAtLeast(2).When(false)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := tc.rule.Validate(tc.val)
+
+			// --- Then ---
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_QuantifierRule_Validate_invalid_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		val  any
+		rule QuantifierRule
+		err  string
+		code string
+	}{
+		{
+			"not iterable",
+			"abc",
+			ContainsAtLeast(1, Required),
+			"must be an iterable",
+			ECInvType,
+		},
+		{
+			"all not iterable",
+			123,
+			ContainsAll(1),
+			"must be an iterable",
+			ECInvType,
+		},
+		{
+			"element internal error",
+			[]string{"a", "b"},
+			ContainsNone(Map()),
+			"0: only a map can be validated",
+			ECInternal,
+		},
+		{
+			"element invalid type",
+			[]string{"a", "b"},
+			ContainsAtLeast(1, Min(1)),
+			"0: cannot convert string to int64",
+			ECInvType,
+		},
+		{
+			"at least one",
+			[]int{1, 2, 3},
+			ContainsAtLeast(1, Min(4)),
+			"must contain at least 1 matching element, found 0",
+			ECInvCount,
+		},
+		{
+			"at least",
+			[]int{1, 2, 3},
+			ContainsAtLeast(3, Min(2)),
+			"must contain at least 3 matching elements, found 2",
+			ECInvCount,
+		},
+		{
+			"at most",
+			[]int{1, 2, 3},
+			ContainsAtMost(1, Min(2)),
+			"must contain no more than 1 matching element, found 2",
+			ECInvCount,
+		},
+		{
+			"exactly less",
+			[]int{1, 2, 3},
+			ContainsExactly(3, Min(2)),
+			"must contain exactly 3 matching elements, found 2",
+			ECInvCount,
+		},
+		{
+			"exactly more",
+			[]int{1, 2, 3},
+			ContainsExactly(1, Min(2)),
+			"must contain exactly 1 matching element, found 2",
+			ECInvCount,
+		},
+		{
+			"none",
+			[]int{1, 2, 3},
+			ContainsNone(Min(3)),
+			"must not contain matching elements, found 1",
+			ECFound,
+		},
+		{
+			"all",
+			[]string{"a", "b"},
+			ContainsAll("a", "c", "d"),
+			"must contain all the values, missing c,d",
+			ECNotFound,
+		},
+		{
+			"custom code",
+			[]int{1},
+			ContainsAtLeast(2).Code("ECCustom"),
+			"must contain at least 2 matching elements, found 1",
+			"ECCustom",
+		},
+		{
+			"custom error",
+			[]int{1},
+			ContainsAtLeast(2).Error(ErrTst),
+			"tst msg",
+			"ETstCode",
+		},
+		{
+			"custom error and code",
+			[]int{1},
+			ContainsAtLeast(2).Error(ErrTst).Code("ECCustom"),
+			"tst msg",
+			"ECCustom",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := tc.rule.Validate(tc.val)
+
+			// --- Then ---
+			assert.ErrorEqual(t, tc.err, err)
+			xrrtest.AssertCode(t, tc.code, err)
+		})
+	}
+}
+
+func Test_QuantifierRule_Validate_metadata(t *testing.T) {
+	t.Run("at least", func(t *testing.T) {
+		// --- When ---
+		err := ContainsAtLeast(3, Min(2)).Validate([]int{1, 2, 3})
+
+		// --- Then ---
+		xrrtest.AssertInt(t, ContainsCount, 2, err)
+		xrrtest.AssertNoKey(t, ContainsIndices, err)
+	})
+
+	t.Run("at most", func(t *testing.T) {
+		// --- When ---
+		err := ContainsAtMost(1, Min(2)).Validate([]int{1, 2, 3})
+
+		// --- Then ---
+		xrrtest.AssertInt(t, ContainsCount, 2, err)
+		xrrtest.AssertStr(t, ContainsIndices, "1,2", err)
+	})
+
+	t.Run("exactly less", func(t *testing.T) {
+		// --- When ---
+		err := ContainsExactly(3, Min(2)).Validate([]int{1, 2, 3})
+
+		// --- Then ---
+		xrrtest.AssertInt(t, ContainsCount, 2, err)
+		xrrtest.AssertNoKey(t, ContainsIndices, err)
+	})
+
+	t.Run("none map keys", func(t *testing.T) {
+		// --- Given ---
+		val := map[string]int{"C": 3, "A": 1, "B": 2}
+
+		// --- When ---
+		err := ContainsNone(Min(2)).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertInt(t, ContainsCount, 2, err)
+		xrrtest.AssertStr(t, ContainsIndices, "B,C", err)
+	})
+
+	t.Run("all", func(t *testing.T) {
+		// --- When ---
+		err := ContainsAll(1, 4, 5).Validate([]int{1, 2, 3})
+
+		// --- Then ---
+		xrrtest.AssertInt(t, ContainsCount, 1, err)
+		xrrtest.AssertStr(t, ContainsMissing, "4,5", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- When ---
+		err := ContainsNone(Min(2)).Error(ErrTst).Validate([]int{1, 2})
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrTst, err)
+		xrrtest.AssertInt(t, ContainsCount, 1, err)
+		xrrtest.AssertStr(t, ContainsIndices, "1", err)
+	})
+}
@@ -0,0 +1,213 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+	"time"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+)
+
+// Message templates for relative time rules.
+var (
+	// tplTimeBefore is an error message template for a time before threshold.
+	tplTimeBefore = emtpl("must be before {{.threshold}}")
+
+	// tplTimeAfter is an error message template for a time after threshold.
+	tplTimeAfter = emtpl("must be after {{.threshold}}")
+
+	// tplTimeNotBefore is an error message template for a time not before
+	// threshold.
+	tplTimeNotBefore = emtpl("must not be before {{.threshold}}")
+
+	// tplTimeBetween is an error message template for a time in a range.
+	tplTimeBetween = emtpl("must be between {{.from}} and {{.to}}")
+)
+
+// Past creates a validation rule that checks if a time is before the current
+// time. The current time is read from the clock when the value is validated,
+// so the rule may be stored in a package-level variable. The value must be
+// [time.Time] or a pointer to it. Zero time is considered valid; use the
+// [Required] rule to ensure a value is not empty.
+func Past() RelTimeRule {
+	return RelTimeRule{kind: relPast, condition: true, code: ECInvThreshold}
+}
+
+// Future creates a validation rule that checks if a time is after the current
+// time. See [Past] for details.
+func Future() RelTimeRule {
+	return RelTimeRule{kind: relFuture, condition: true, code: ECInvThreshold}
+}
+
+// Within creates a validation rule that checks if a time is within the given
+// duration from the current time, in the past or in the future. The range is
+// inclusive. See [Past] for details.
+//
+// Example:
+//
+//	rule := Within(5 * time.Minute) // Value must be now ± 5 minutes.
+func Within(d time.Duration) RelTimeRule {
+	return RelTimeRule{
+		kind:      relWithin,
+		d:         d,
+		condition: true,
+		code:      ECInvThreshold,
+	}
+}
+
+// OlderThan creates a validation rule that checks if a time is before the
+// current time by more than the given duration. See [Past] for details.
+//
+// Example:
+//
+//	rule := OlderThan(18 * 365 * 24 * time.Hour) // Value must be < now - d.
+func OlderThan(d time.Duration) RelTimeRule {
+	return RelTimeRule{
+		kind:      relOlderThan,
+		d:         d,
+		condition: true,
+		code:      ECInvThreshold,
+	}
+}
+
+// NotBefore creates a validation rule that checks if a time is not before the
+// current time moved by the given duration. The duration may be negative. See
+// [Past] for details.
+//
+// Example:
+//
+//	rule := NotBefore(time.Hour)       // Value must be >= now + 1h.
+//	rule := NotBefore(-24 * time.Hour) // Value must be >= now - 24h.
+func NotBefore(d time.Duration) RelTimeRule {
+	return RelTimeRule{
+		kind:      relNotBefore,
+		d:         d,
+		condition: true,
+		code:      ECInvThreshold,
+	}
+}
+
+// Relative time rule kinds.
+const (
+	relPast      = iota // Time must be before now.
+	relFuture           // Time must be after now.
+	relWithin           // Time must be within now ± duration.
+	relOlderThan        // Time must be before now - duration.
+	relNotBefore        // Time must not be before now + duration.
+)
+
+// Compile time checks.
+var (
+	_ Customizer[RelTimeRule]  = RelTimeRule{}
+	_ Conditioner[RelTimeRule] = RelTimeRule{}
+)
+
+// RelTimeRule is a rule validating a time relative to the current time.
+type RelTimeRule struct {
+	kind      int           // The rule kind.
+	d         time.Duration // The duration relative to the current time.
+	clock     Clock         // The clock, the system clock when nil.
+	condition bool          // Run validation only when true.
+	err       error         // Custom error.
+	code      string        // Error code.
+}
+
+// Clock sets the clock used to read the current time. By default, the
+// [SystemClock] is used.
+func (r RelTimeRule) Clock(clock Clock) RelTimeRule {
+	r.clock = clock
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r RelTimeRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	if isNil, _ := IsNil(v); isNil {
+		return nil
+	}
+	if IsEmpty(v) {
+		return nil
+	}
+
+	tim, ok := Indirect(v).(time.Time)
+	if !ok {
+		msg := fmt.Sprintf("cannot convert %T to time.Time", v)
+		return xrr.New(msg, ECInvType)
+	}
+
+	now := time.Now()
+	if r.clock != nil {
+		now = r.clock.Now()
+	}
+
+	var tpl *template.Template
+	data := map[string]any{}
+	switch r.kind {
+	case relPast:
+		if tim.Before(now) {
+			return nil
+		}
+		tpl, data["threshold"] = tplTimeBefore, format(now)
+
+	case relFuture:
+		if tim.After(now) {
+			return nil
+		}
+		tpl, data["threshold"] = tplTimeAfter, format(now)
+
+	case relWithin:
+		from, to := now.Add(-r.d), now.Add(r.d)
+		if !tim.Before(from) && !tim.After(to) {
+			return nil
+		}
+		tpl, data["from"], data["to"] = tplTimeBetween, format(from), format(to)
+
+	case relOlderThan:
+		th := now.Add(-r.d)
+		if tim.Before(th) {
+			return nil
+		}
+		tpl, data["threshold"] = tplTimeBefore, format(th)
+
+	case relNotBefore:
+		th := now.Add(r.d)
+		if !tim.Before(th) {
+			return nil
+		}
+		tpl, data["threshold"] = tplTimeNotBefore, format(th)
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+	buf := bytes.Buffer{}
+	_ = tpl.Execute(&buf, data)
+	return xrr.New(buf.String(), r.code)
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r RelTimeRule) When(condition bool) RelTimeRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r RelTimeRule) Code(code string) RelTimeRule {
+	r.code = code
+	r.err = setCode(r.err, code)
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r RelTimeRule) Error(err error) RelTimeRule {
+	r.err = err
+	return r
+}
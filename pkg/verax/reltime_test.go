@@ -0,0 +1,293 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+)
+
+// tstNow is the current time used in relative time rule tests.
+var tstNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func Test_Past(t *testing.T) {
+	// --- When ---
+	have := Past()
+
+	// --- Then ---
+	assert.Equal(t, relPast, have.kind)
+	assert.Equal(t, time.Duration(0), have.d)
+	assert.Nil(t, have.clock)
+	assert.True(t, have.condition)
+	assert.Nil(t, have.err)
+	assert.Equal(t, ECInvThreshold, have.code)
+}
+
+func Test_Future(t *testing.T) {
+	// --- When ---
+	have := Future()
+
+	// --- Then ---
+	assert.Equal(t, relFuture, have.kind)
+	assert.True(t, have.condition)
+	assert.Equal(t, ECInvThreshold, have.code)
+}
+
+func Test_Within(t *testing.T) {
+	// --- When ---
+	have := Within(time.Hour)
+
+	// --- Then ---
+	assert.Equal(t, relWithin, have.kind)
+	assert.Equal(t, time.Hour, have.d)
+	assert.True(t, have.condition)
+	assert.Equal(t, ECInvThreshold, have.code)
+}
+
+func Test_OlderThan(t *testing.T) {
+	// --- When ---
+	have := OlderThan(time.Hour)
+
+	// --- Then ---
+	assert.Equal(t, relOlderThan, have.kind)
+	assert.Equal(t, time.Hour, have.d)
+	assert.True(t, have.condition)
+	assert.Equal(t, ECInvThreshold, have.code)
+}
+
+func Test_NotBefore(t *testing.T) {
+	// --- When ---
+	have := NotBefore(time.Hour)
+
+	// --- Then ---
+	assert.Equal(t, relNotBefore, have.kind)
+	assert.Equal(t, time.Hour, have.d)
+	assert.True(t, have.condition)
+	assert.Equal(t, ECInvThreshold, have.code)
+}
+
+func Test_RelTimeRule_Clock(t *testing.T) {
+	// --- Given ---
+	clock := NewSettableClock(tstNow)
+
+	// --- When ---
+	have := Past().Clock(clock)
+
+	// --- Then ---
+	assert.Same(t, clock, have.clock)
+}
+
+func Test_RelTimeRule_Validate(t *testing.T) {
+	t.Run("nil", func(t *testing.T) {
+		// --- When ---
+		err := Past().Validate(nil)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("nil pointer", func(t *testing.T) {
+		// --- Given ---
+		var tim *time.Time
+
+		// --- When ---
+		err := Past().Validate(tim)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("zero time", func(t *testing.T) {
+		// --- When ---
+		err := Future().Validate(time.Time{})
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("pointer", func(t *testing.T) {
+		// --- Given ---
+		clock := NewSettableClock(tstNow)
+		tim := tstNow.Add(-time.Second)
+
+		// --- When ---
+		err := Past().Clock(clock).Validate(&tim)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("not supported type", func(t *testing.T) {
+		// --- When ---
+		err := Past().Validate(42)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "cannot convert int to time.Time (ECInvType)", err)
+	})
+
+	t.Run("clock is read at validation time", func(t *testing.T) {
+		// --- Given ---
+		clock := NewSettableClock(tstNow)
+		rule := Past().Clock(clock)
+		tim := tstNow.Add(time.Minute)
+
+		// --- When ---
+		errBefore := rule.Validate(tim)
+		clock.Advance(time.Hour)
+		errAfter := rule.Validate(tim)
+
+		// --- Then ---
+		assert.Error(t, errBefore)
+		assert.NoError(t, errAfter)
+	})
+
+	t.Run("system clock", func(t *testing.T) {
+		// --- When ---
+		err := Past().Validate(time.Now().Add(-time.Minute))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := Past().When(false).Validate(42)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- Given ---
+		clock := NewSettableClock(tstNow)
+
+		// --- When ---
+		err := Past().Clock(clock).Code("MyCode").Validate(tstNow)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be before 2025-06-15T12:00:00Z (MyCode)",
+			err,
+		)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		clock := NewSettableClock(tstNow)
+		my := errors.New("my error")
+
+		// --- When ---
+		err := Past().Clock(clock).Error(my).Validate(tstNow)
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+
+	t.Run("custom error code for custom error", func(t *testing.T) {
+		// --- Given ---
+		clock := NewSettableClock(tstNow)
+		my := errors.New("my error")
+		rule := Past().Clock(clock).Error(my).Code("MyCode")
+
+		// --- When ---
+		err := rule.Validate(tstNow)
+
+		// --- Then ---
+		assert.ErrorIs(t, my, err)
+		xrrtest.AssertCode(t, "MyCode", err)
+	})
+}
+
+func Test_RelTimeRule_Validate_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule RelTimeRule
+		tim  time.Time
+		want string
+	}{
+		{"past", Past(), tstNow.Add(-time.Nanosecond), ""},
+		{"past now", Past(), tstNow, "must be before 2025-06-15T12:00:00Z"},
+		{
+			"past future",
+			Past(),
+			tstNow.Add(time.Hour),
+			"must be before 2025-06-15T12:00:00Z",
+		},
+		{"future", Future(), tstNow.Add(time.Nanosecond), ""},
+		{"future now", Future(), tstNow, "must be after 2025-06-15T12:00:00Z"},
+		{
+			"future past",
+			Future(),
+			tstNow.Add(-time.Hour),
+			"must be after 2025-06-15T12:00:00Z",
+		},
+		{"within now", Within(time.Hour), tstNow, ""},
+		{"within past edge", Within(time.Hour), tstNow.Add(-time.Hour), ""},
+		{"within future edge", Within(time.Hour), tstNow.Add(time.Hour), ""},
+		{
+			"within too old",
+			Within(time.Hour),
+			tstNow.Add(-2 * time.Hour),
+			"must be between 2025-06-15T11:00:00Z and 2025-06-15T13:00:00Z",
+		},
+		{
+			"within too new",
+			Within(time.Hour),
+			tstNow.Add(2 * time.Hour),
+			"must be between 2025-06-15T11:00:00Z and 2025-06-15T13:00:00Z",
+		},
+		{"older than", OlderThan(time.Hour), tstNow.Add(-2 * time.Hour), ""},
+		{
+			"older than edge",
+			OlderThan(time.Hour),
+			tstNow.Add(-time.Hour),
+			"must be before 2025-06-15T11:00:00Z",
+		},
+		{
+			"older than too new",
+			OlderThan(time.Hour),
+			tstNow,
+			"must be before 2025-06-15T11:00:00Z",
+		},
+		{"not before", NotBefore(time.Hour), tstNow.Add(2 * time.Hour), ""},
+		{"not before edge", NotBefore(time.Hour), tstNow.Add(time.Hour), ""},
+		{
+			"not before too early",
+			NotBefore(time.Hour),
+			tstNow,
+			"must not be before 2025-06-15T13:00:00Z",
+		},
+		{"not before negative", NotBefore(-time.Hour), tstNow, ""},
+		{
+			"not before negative too early",
+			NotBefore(-time.Hour),
+			tstNow.Add(-2 * time.Hour),
+			"must not be before 2025-06-15T11:00:00Z",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- Given ---
+			rule := tc.rule.Clock(NewSettableClock(tstNow))
+
+			// --- When ---
+			err := rule.Validate(tc.tim)
+
+			// --- Then ---
+			if tc.want == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorEqual(t, tc.want, err)
+				xrrtest.AssertCode(t, ECInvThreshold, err)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package rule
 
 import (
+	"encoding/base32"
+	"encoding/base64"
+	"math"
 	"regexp"
+	"strings"
 
 	"github.com/ctx42/xrr/pkg/xrr"
 
@@ -20,11 +24,56 @@ var (
 	base64Rxc = regexp.MustCompile(base64Rx)
 )
 
+// base58Alphabet represents the Bitcoin base58 alphabet.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZ" +
+	"abcdefghijkmnopqrstuvwxyz"
+
 // Validation errors.
 var (
 	// ErrBase64 is the error that returns in the case of an invalid base64
 	// value.
 	ErrBase64 = xrr.New("must be a valid base64", "ECBase64")
+
+	// ErrBase64URL is the error that returns in the case of an invalid
+	// base64url value.
+	ErrBase64URL = xrr.New("must be a valid base64url", "ECBase64URL")
+
+	// ErrBase64Raw is the error that returns in the case of an invalid
+	// unpadded base64 value.
+	ErrBase64Raw = xrr.New("must be a valid unpadded base64", "ECBase64Raw")
+
+	// ErrBase64RawURL is the error that returns in the case of an invalid
+	// unpadded base64url value.
+	ErrBase64RawURL = xrr.New(
+		"must be a valid unpadded base64url",
+		"ECBase64RawURL",
+	)
+
+	// ErrBase32 is the error that returns in the case of an invalid base32
+	// value.
+	ErrBase32 = xrr.New("must be a valid base32", "ECBase32")
+
+	// ErrBase58 is the error that returns in the case of an invalid base58
+	// value.
+	ErrBase58 = xrr.New("must be a valid base58", "ECBase58")
+
+	// ErrHex is the error that returns in the case of an invalid hexadecimal
+	// value.
+	ErrHex = xrr.New("must be a valid hexadecimal", "ECHex")
+
+	// ErrHexCase is the error that returns in the case of a hexadecimal value
+	// with letters of not allowed case.
+	ErrHexCase = xrr.New(
+		"must be a hexadecimal with letters of valid case",
+		"ECHexCase",
+	)
+
+	// ErrDecodedLength is the error that returns in the case of an encoded
+	// value which decodes to a value of invalid length.
+	ErrDecodedLength = xrr.New(
+		"must decode to a value of valid length",
+		"ECDecodedLength",
+	)
 )
 
 // IsBase64 checks if a string is valid base64.
@@ -37,3 +86,344 @@ func IsBase64(str string) bool {
 
 // Base64 validates if a string is a valid base64.
 var Base64 = verax.String(IsBase64).Error(ErrBase64)
+
+// Base64Std validates if a string is a valid padded base64 (RFC 4648 section
+// 4). It accepts the same strings as [Base64], but it is an [EncodingRule], so
+// the decoded length may be constrained.
+//
+// Example:
+//
+//	rule := Base64Std.DecodedLen(32, 32) // 32 bytes of key material.
+var Base64Std = Encoding(decodeBase64, ErrBase64)
+
+// IsBase64URL checks if a string is valid padded base64url (RFC 4648 section
+// 5).
+func IsBase64URL(str string) bool { return Base64URL.check(str) == nil }
+
+// Base64URL validates if a string is a valid padded base64url.
+var Base64URL = Encoding(decodeWith(base64.URLEncoding), ErrBase64URL)
+
+// IsBase64Raw checks if a string is valid unpadded base64.
+func IsBase64Raw(str string) bool { return Base64Raw.check(str) == nil }
+
+// Base64Raw validates if a string is a valid unpadded base64.
+var Base64Raw = Encoding(decodeWith(base64.RawStdEncoding), ErrBase64Raw)
+
+// IsBase64RawURL checks if a string is valid unpadded base64url.
+func IsBase64RawURL(str string) bool { return Base64RawURL.check(str) == nil }
+
+// Base64RawURL validates if a string is a valid unpadded base64url.
+var Base64RawURL = Encoding(
+	decodeWith(base64.RawURLEncoding),
+	ErrBase64RawURL,
+)
+
+// IsBase32 checks if a string is valid padded base32 (RFC 4648 section 6).
+func IsBase32(str string) bool { return Base32.check(str) == nil }
+
+// Base32 validates if a string is a valid padded base32.
+var Base32 = Encoding(decodeWith(base32.StdEncoding), ErrBase32)
+
+// IsBase58 checks if a string is valid base58 using the Bitcoin alphabet.
+func IsBase58(str string) bool { return Base58.check(str) == nil }
+
+// Base58 validates if a string is a valid base58 using the Bitcoin alphabet.
+// Decoding base58 takes quadratic time, so the value is decoded only when the
+// decoded length cannot be determined from the string length, which happens
+// only when [EncodingRule.DecodedLen] is used with the bounds close to it.
+var Base58 = EncodingRule{
+	decode:    decodeBase58,
+	bounds:    base58Bounds,
+	invalid:   ErrBase58,
+	condition: true,
+}
+
+// DecodeFunc is a function type decoding a string. It returns the length of
+// the decoded value in bytes and false when the string is not valid.
+type DecodeFunc func(str string) (int, bool)
+
+// Encoding returns a rule which checks a string is valid according to the
+// decode function. The err is returned for strings which cannot be decoded.
+// Use [EncodingRule.DecodedLen] to constrain the length of the decoded value.
+func Encoding(fn DecodeFunc, err error) EncodingRule {
+	return EncodingRule{decode: fn, invalid: err, condition: true}
+}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[EncodingRule]  = EncodingRule{}
+	_ verax.Conditioner[EncodingRule] = EncodingRule{}
+)
+
+// EncodingRule is a rule that checks a string is a valid encoded value.
+type EncodingRule struct {
+	decode    DecodeFunc // Decoding function.
+	bounds    boundsFunc // Decoded length bounds function, optional.
+	invalid   error      // Error for values which cannot be decoded.
+	min       int        // Minimum decoded length.
+	max       int        // Maximum decoded length, no limit when zero.
+	condition bool       // Run validation only when true.
+	code      string     // Custom error code.
+	err       error      // Custom error.
+}
+
+// DecodedLen configures the rule to check the length of the decoded value in
+// bytes is within the specified range. If the maximum is 0, there is no upper
+// bound for the length. The [ErrDecodedLength] is returned for values of
+// invalid length.
+func (r EncodingRule) DecodedLen(minimum, maximum int) EncodingRule {
+	r.min, r.max = minimum, maximum
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r EncodingRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r EncodingRule) When(condition bool) EncodingRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r EncodingRule) Code(code string) EncodingRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r EncodingRule) Error(err error) EncodingRule {
+	r.err = err
+	return r
+}
+
+// check returns an error if the string is not valid.
+func (r EncodingRule) check(str string) error {
+	if str == "" || strings.ContainsAny(str, "\r\n") {
+		return r.invalid
+	}
+	if r.bounds != nil {
+		lo, hi, ok := r.bounds(str)
+		if !ok {
+			return r.invalid
+		}
+		if hi < r.min || (r.max > 0 && lo > r.max) {
+			return ErrDecodedLength
+		}
+		if lo >= r.min && (r.max == 0 || hi <= r.max) {
+			return nil
+		}
+	}
+	n, ok := r.decode(str)
+	if !ok {
+		return r.invalid
+	}
+	return checkDecodedLen(n, r.min, r.max)
+}
+
+// IsHex checks if a string is a valid hexadecimal value. Letters of any case
+// are allowed, and the length may be odd.
+func IsHex(str string) bool { return Hex.check(str) == nil }
+
+// Hex validates if a string is a valid hexadecimal value. By default, letters
+// of any case are allowed, and the length may be odd. Use [HexRule] methods
+// to change the defaults.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrHex] - the string is not hexadecimal or has odd length.
+//   - [ErrHexCase] - the letters are of not allowed case.
+//   - [ErrDecodedLength] - the decoded value has invalid length.
+var Hex = HexRule{condition: true}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[HexRule]  = HexRule{}
+	_ verax.Conditioner[HexRule] = HexRule{}
+)
+
+// HexRule is a rule that checks a string is a valid hexadecimal value.
+type HexRule struct {
+	even      bool   // Require even length.
+	letters   string // Allowed letters, all when empty.
+	min       int    // Minimum decoded length.
+	max       int    // Maximum decoded length, no limit when zero.
+	condition bool   // Run validation only when true.
+	code      string // Custom error code.
+	err       error  // Custom error.
+}
+
+// EvenLength configures the rule to require even length, so the value
+// decodes to whole bytes.
+func (r HexRule) EvenLength() HexRule {
+	r.even = true
+	return r
+}
+
+// Lower configures the rule to allow only lower case letters.
+func (r HexRule) Lower() HexRule {
+	r.letters = "abcdef"
+	return r
+}
+
+// Upper configures the rule to allow only upper case letters.
+func (r HexRule) Upper() HexRule {
+	r.letters = "ABCDEF"
+	return r
+}
+
+// DecodedLen configures the rule to check the length of the decoded value in
+// bytes is within the specified range. The odd length value decodes to the
+// number of bytes rounded up. If the maximum is 0, there is no upper bound for
+// the length.
+func (r HexRule) DecodedLen(minimum, maximum int) HexRule {
+	r.min, r.max = minimum, maximum
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r HexRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r HexRule) When(condition bool) HexRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r HexRule) Code(code string) HexRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r HexRule) Error(err error) HexRule {
+	r.err = err
+	return r
+}
+
+// check returns an error describing why the string is not a valid
+// hexadecimal value or nil if it is valid.
+func (r HexRule) check(str string) error {
+	if str == "" || (r.even && len(str)%2 != 0) {
+		return ErrHex
+	}
+	var badCase bool
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch {
+		case isDigit(c):
+		case (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'):
+			if r.letters != "" && strings.IndexByte(r.letters, c) == -1 {
+				badCase = true
+			}
+		default:
+			return ErrHex
+		}
+	}
+	if badCase {
+		return ErrHexCase
+	}
+	return checkDecodedLen((len(str)+1)/2, r.min, r.max)
+}
+
+// checkDecodedLen checks the decoded length is within the range.
+func checkDecodedLen(n, minimum, maximum int) error {
+	if n < minimum || (maximum > 0 && n > maximum) {
+		return ErrDecodedLength
+	}
+	return nil
+}
+
+// boundsFunc is a function type returning the minimum and maximum length of
+// the decoded string without decoding it. It returns false when the string is
+// not valid.
+type boundsFunc func(str string) (int, int, bool)
+
+// decoder represents a decoder with the [base64.Encoding] interface.
+type decoder interface {
+	DecodeString(s string) ([]byte, error)
+}
+
+// decodeWith returns [DecodeFunc] using the given decoder.
+func decodeWith(enc decoder) DecodeFunc {
+	return func(str string) (int, bool) {
+		bs, err := enc.DecodeString(str)
+		return len(bs), err == nil
+	}
+}
+
+// decodeBase64 decodes padded standard base64 string.
+func decodeBase64(str string) (int, bool) {
+	if !IsBase64(str) {
+		return 0, false
+	}
+	return decodeWith(base64.StdEncoding)(str)
+}
+
+// decodeBase58 decodes base58 string using the Bitcoin alphabet.
+func decodeBase58(str string) (int, bool) {
+	var zeros int
+	for zeros < len(str) && str[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	// Big-endian base256 digits of the number.
+	var num []byte
+	for i := zeros; i < len(str); i++ {
+		carry := strings.IndexByte(base58Alphabet, str[i])
+		if carry == -1 {
+			return 0, false
+		}
+		for j := len(num) - 1; j >= 0; j-- {
+			carry += int(num[j]) * 58
+			num[j] = byte(carry)
+			carry >>= 8
+		}
+		for ; carry > 0; carry >>= 8 {
+			num = append([]byte{byte(carry)}, num...)
+		}
+	}
+	return zeros + len(num), true
+}
+
+// base58Bounds returns the minimum and maximum length of the decoded base58
+// string using the Bitcoin alphabet. Each leading "1" decodes to a zero byte.
+// The rest of the string is a big-endian number of k base58 digits, which is
+// in the range [58^(k-1), 58^k), so it decodes to the number of bytes in the
+// range [floor((k-1)*log256(58))+1, floor(k*log256(58))+1].
+func base58Bounds(str string) (int, int, bool) {
+	var zeros int
+	for zeros < len(str) && str[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+	for i := zeros; i < len(str); i++ {
+		if strings.IndexByte(base58Alphabet, str[i]) == -1 {
+			return 0, 0, false
+		}
+	}
+	k := float64(len(str) - zeros)
+	if k == 0 {
+		return zeros, zeros, true
+	}
+	c := math.Log2(58) / 8
+	lo := zeros + int(math.Floor((k-1)*c)) + 1
+	hi := zeros + int(math.Floor(k*c)) + 1
+	return lo, hi, true
+}
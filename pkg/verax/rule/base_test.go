@@ -1,12 +1,16 @@
 package rule
 
 import (
+	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/ctx42/testing/pkg/assert"
 	"github.com/ctx42/testing/pkg/must"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
 
 	"github.com/ctx42/verax/pkg/verax"
 )
@@ -120,4 +124,599 @@ func Test_Base64(t *testing.T) {
 		// --- Then ---
 		assert.ErrorIs(t, ErrBase64, err)
 	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", Base64.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid base64 (ECMy)", err)
+	})
+}
+
+func Test_Base64Std(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		val := base64.StdEncoding.EncodeToString([]byte("test"))
+
+		// --- When ---
+		err := verax.Validate(val, Base64Std)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", Base64Std)
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrBase64, err)
+	})
+
+	t.Run("decoded length", func(t *testing.T) {
+		// --- Given ---
+		val := base64.StdEncoding.EncodeToString(make([]byte, 32))
+
+		// --- When ---
+		err := verax.Validate(val, Base64Std.DecodedLen(32, 32))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error decoded length", func(t *testing.T) {
+		// --- Given ---
+		val := base64.StdEncoding.EncodeToString(make([]byte, 31))
+
+		// --- When ---
+		err := verax.Validate(val, Base64Std.DecodedLen(32, 32))
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must decode to a value of valid length (ECDecodedLength)",
+			err,
+		)
+	})
+}
+
+func Test_IsBase64URL_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"padded", "ACAwQFA_Mw==", true},
+		{"no padding needed", "dGVz", true},
+		{"unpadded", "ACAwQFA_Mw", false},
+		{"standard alphabet", "ACAwQFA/Mw==", false},
+		{"new line", "dGVz\ndGVz", false},
+		{"invalid character", "dGV*", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsBase64URL(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IsBase64Raw_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"unpadded", "MgD/Og", true},
+		{"no padding needed", "dGVz", true},
+		{"padded", "MgD/Og==", false},
+		{"url alphabet", "MgD_Og", false},
+		{"invalid length", "MgD/O", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsBase64Raw(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IsBase64RawURL_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"unpadded", "MgD_Og", true},
+		{"no padding needed", "dGVz", true},
+		{"padded", "MgD_Og==", false},
+		{"standard alphabet", "MgD/Og", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsBase64RawURL(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IsBase32_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"padded", "ORSXG5A=", true},
+		{"no padding needed", "ORSXG5BA", true},
+		{"unpadded", "ORSXG5A", false},
+		{"lower case", "orsxg5a=", false},
+		{"invalid character", "ORSXG51=", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsBase32(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IsBase58_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"text", "StV1DL6CwTryKyV", true},
+		{"leading zeros", "11StV1DL6CwTryKyV", true},
+		{"only zeros", "111", true},
+		{"zero character", "StV0DL6CwTryKyV", false},
+		{"letter O", "StVODL6CwTryKyV", false},
+		{"letter I", "StVIDL6CwTryKyV", false},
+		{"letter l", "StVlDL6CwTryKyV", false},
+		{"plus", "StV+DL6CwTryKyV", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsBase58(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_decodeBase58_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want int
+	}{
+		{"text", "StV1DL6CwTryKyV", 11},
+		{"leading zeros", "11StV1DL6CwTryKyV", 13},
+		{"only zeros", "111", 3},
+		{"one byte", "2", 1},
+		{"max one byte", "5Q", 1},
+		{"two bytes", "5R", 2},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have, ok := decodeBase58(tc.str)
+
+			// --- Then ---
+			assert.True(t, ok)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_base58Bounds_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str    string
+		wantLo int
+		wantHi int
+	}{
+		{"only zeros", "111", 3, 3},
+		{"one digit", "2", 1, 1},
+		{"two digits", "5Q", 1, 2},
+		{"leading zeros", "11StV1DL6CwTryKyV", 13, 13},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			lo, hi, ok := base58Bounds(tc.str)
+
+			// --- Then ---
+			assert.True(t, ok)
+			assert.Equal(t, tc.wantLo, lo)
+			assert.Equal(t, tc.wantHi, hi)
+		})
+	}
+}
+
+func Test_base58Bounds_contain_decoded_length(t *testing.T) {
+	// --- Given ---
+	strs := []string{
+		"2", "z", "21", "zz", "211", "zzz", "StV1DL6CwTryKyV",
+		strings.Repeat("z", 50), "1" + strings.Repeat("2", 50),
+	}
+
+	for _, str := range strs {
+		// --- When ---
+		lo, hi, _ := base58Bounds(str)
+		have, _ := decodeBase58(str)
+
+		// --- Then ---
+		assert.True(t, lo <= have && have <= hi)
+	}
+}
+
+func Test_Base58_long_input(t *testing.T) {
+	t.Run("no length bounds", func(t *testing.T) {
+		// --- Given ---
+		str := strings.Repeat("z", 1_000_000)
+
+		// --- When ---
+		err := verax.Validate(str, Base58)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error too long", func(t *testing.T) {
+		// --- Given ---
+		str := strings.Repeat("z", 1_000_000)
+
+		// --- When ---
+		err := verax.Validate(str, Base58.DecodedLen(0, 32))
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrDecodedLength, err)
+	})
+
+	t.Run("error invalid character", func(t *testing.T) {
+		// --- Given ---
+		str := strings.Repeat("z", 1_000_000) + "0"
+
+		// --- When ---
+		err := verax.Validate(str, Base58.DecodedLen(0, 32))
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrBase58, err)
+	})
+}
+
+func Test_EncodingRule(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		val := base64.RawURLEncoding.EncodeToString(make([]byte, 32))
+
+		// --- When ---
+		err := verax.Validate(val, Base64RawURL)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", Base64RawURL)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("MgD/Og", Base64RawURL)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be a valid unpadded base64url (ECBase64RawURL)",
+			err,
+		)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("!", Base32.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- Given ---
+		rule := Base32.DecodedLen(10, 0).Code("ECMy")
+
+		// --- When ---
+		errFmt := verax.Validate("!", rule)
+		errLen := verax.Validate("ORSXG5A=", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid base32 (ECMy)", errFmt)
+		xrrtest.AssertCode(t, "ECMy", errLen)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("!", Base58.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_EncodingRule_DecodedLen_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule EncodingRule
+		str  string
+		want error
+	}{
+		{
+			"base64url exact",
+			Base64URL.DecodedLen(4, 4),
+			base64.URLEncoding.EncodeToString(make([]byte, 4)),
+			nil,
+		},
+		{
+			"base64url too short",
+			Base64URL.DecodedLen(5, 0),
+			base64.URLEncoding.EncodeToString(make([]byte, 4)),
+			ErrDecodedLength,
+		},
+		{
+			"base64 raw too long",
+			Base64Raw.DecodedLen(1, 3),
+			base64.RawStdEncoding.EncodeToString(make([]byte, 4)),
+			ErrDecodedLength,
+		},
+		{
+			"base32 no upper bound",
+			Base32.DecodedLen(1, 0),
+			base32.StdEncoding.EncodeToString(make([]byte, 100)),
+			nil,
+		},
+		{
+			"base58 exact",
+			Base58.DecodedLen(11, 11),
+			"StV1DL6CwTryKyV",
+			nil,
+		},
+		{
+			"base58 leading zeros",
+			Base58.DecodedLen(11, 11),
+			"1StV1DL6CwTryKyV",
+			ErrDecodedLength,
+		},
+		{
+			"base58 max one byte",
+			Base58.DecodedLen(1, 1),
+			"5Q",
+			nil,
+		},
+		{
+			"base58 two bytes",
+			Base58.DecodedLen(1, 1),
+			"5R",
+			ErrDecodedLength,
+		},
+		{
+			"base58 two bytes minimum",
+			Base58.DecodedLen(2, 0),
+			"5Q",
+			ErrDecodedLength,
+		},
+		{
+			"invalid format reported first",
+			Base58.DecodedLen(11, 11),
+			"0",
+			ErrBase58,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.str, tc.rule)
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_Encoding(t *testing.T) {
+	// --- Given ---
+	fn := func(str string) (int, bool) { return len(str), str != "bad" }
+	myErr := errors.New("my error")
+	rule := Encoding(fn, myErr).DecodedLen(2, 0)
+
+	// --- When ---
+	errOK := verax.Validate("abc", rule)
+	errBad := verax.Validate("bad", rule)
+	errLen := verax.Validate("a", rule)
+
+	// --- Then ---
+	assert.NoError(t, errOK)
+	assert.Same(t, myErr, errBad)
+	assert.ErrorIs(t, ErrDecodedLength, errLen)
+}
+
+func Test_IsHex_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"lower case", "deadbeef", true},
+		{"upper case", "DEADBEEF", true},
+		{"mixed case", "DeadBeef", true},
+		{"digits", "0123456789", true},
+		{"odd length", "fff", true},
+		{"prefix", "0xff", false},
+		{"invalid letter", "abcg", false},
+		{"space", "ab cd", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsHex(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_Hex(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("deadbeef", Hex)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", Hex)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("xyz", Hex)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid hexadecimal (ECHex)", err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("xyz", Hex.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("xyz", Hex.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid hexadecimal (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("xyz", Hex.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_HexRule_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule HexRule
+		str  string
+		want error
+	}{
+		{"even length", Hex.EvenLength(), "abcd", nil},
+		{"even length odd", Hex.EvenLength(), "abc", ErrHex},
+		{"lower", Hex.Lower(), "deadbeef", nil},
+		{"lower upper case", Hex.Lower(), "deadBeef", ErrHexCase},
+		{"upper", Hex.Upper(), "DEADBEEF", nil},
+		{"upper lower case", Hex.Upper(), "DEADBEEf", ErrHexCase},
+		{"upper digits only", Hex.Upper(), "0123", nil},
+		{"invalid before case", Hex.Upper(), "abz", ErrHex},
+		{"decoded length", Hex.DecodedLen(32, 32), strings.Repeat("ab", 32), nil},
+		{
+			"decoded length too short",
+			Hex.DecodedLen(32, 32),
+			strings.Repeat("ab", 31),
+			ErrDecodedLength,
+		},
+		{
+			"decoded length odd rounded up",
+			Hex.DecodedLen(2, 2),
+			"abc",
+			nil,
+		},
+		{
+			"combined",
+			Hex.EvenLength().Lower().DecodedLen(2, 0),
+			"ab",
+			ErrDecodedLength,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.str, tc.rule)
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
 }
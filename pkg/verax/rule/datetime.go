@@ -0,0 +1,243 @@
+package rule
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+	"time"
+	_ "time/tzdata" // Embedded IANA Time Zone database for TimeZone rule.
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// Validation errors.
+var (
+	// ErrRFC3339 is the error that returns in case of an invalid RFC 3339 date
+	// and time.
+	ErrRFC3339 = xrr.New("must be a valid RFC 3339 date and time", "ECRFC3339")
+
+	// ErrDateLayout is the error that returns in case of a date and time not
+	// matching the required layout.
+	ErrDateLayout = xrr.New(
+		"must be a valid date and time in the required format",
+		"ECDateLayout",
+	)
+
+	// ErrDuration is the error that returns in case of an invalid duration.
+	ErrDuration = xrr.New("must be a valid duration", "ECDuration")
+
+	// ErrTimeZone is the error that returns in case of an invalid IANA Time
+	// Zone name.
+	ErrTimeZone = xrr.New("must be a valid time zone", "ECTimeZone")
+
+	// ErrISO8601Duration is the error that returns in case of an invalid ISO
+	// 8601 duration.
+	ErrISO8601Duration = xrr.New(
+		"must be a valid ISO 8601 duration",
+		"ECISO8601Duration",
+	)
+)
+
+// IsRFC3339 checks if a string is a valid RFC 3339 date and time, e.g.
+// "2006-01-02T15:04:05Z" or "2006-01-02T15:04:05.999+07:00".
+func IsRFC3339(str string) bool {
+	_, err := time.Parse(time.RFC3339, str)
+	return err == nil
+}
+
+// RFC3339 validates if a string is a valid RFC 3339 date and time.
+var RFC3339 = verax.String(IsRFC3339).Error(ErrRFC3339)
+
+// DateLayout returns a rule that checks a string is a valid date and time in
+// the given layout. See [time.Parse] for the layout format.
+//
+// Example:
+//
+//	rule := DateLayout(time.DateOnly) // "2006-01-02"
+func DateLayout(layout string) verax.StringRule {
+	fn := func(str string) bool {
+		_, err := time.Parse(layout, str)
+		return err == nil
+	}
+	return verax.String(fn).Error(ErrDateLayout)
+}
+
+// IsDuration checks if a string is a valid duration as accepted by
+// [time.ParseDuration], e.g. "300ms" or "1h30m".
+func IsDuration(str string) bool {
+	_, err := time.ParseDuration(str)
+	return err == nil
+}
+
+// Duration validates if a string is a valid duration. See [IsDuration].
+var Duration = verax.String(IsDuration).Error(ErrDuration)
+
+// IsTimeZone checks if a string is a valid IANA Time Zone database name, e.g.
+// "Europe/Warsaw" or "UTC". The "Local" name is not valid. The names are
+// looked up as by [time.LoadLocation]: in the ZONEINFO file, the system time
+// zone database, and finally in the embedded one, so the result may differ
+// between systems with different time zone database versions.
+func IsTimeZone(str string) bool {
+	if str == "" || str == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(str)
+	return err == nil
+}
+
+// TimeZone validates if a string is a valid IANA Time Zone name.
+var TimeZone = verax.String(IsTimeZone).Error(ErrTimeZone)
+
+// IsISO8601Duration checks if a string is a valid ISO 8601 duration, e.g.
+// "P1Y2M10DT2H30M", "PT0.5S" or "P2W". At least one component is required,
+// and only the last component may have a fraction.
+func IsISO8601Duration(str string) bool {
+	rest, ok := strings.CutPrefix(str, "P")
+	if !ok || rest == "" {
+		return false
+	}
+	date, tim, hasTime := strings.Cut(rest, "T")
+	if hasTime && tim == "" {
+		return false
+	}
+	if !isISO8601DurationPart(date, "YMWD", !hasTime) {
+		return false
+	}
+	return isISO8601DurationPart(tim, "HMS", true)
+}
+
+// ISO8601Duration validates if a string is a valid ISO 8601 duration.
+var ISO8601Duration = verax.String(IsISO8601Duration).Error(ErrISO8601Duration)
+
+// isISO8601DurationPart checks the date or time part of ISO 8601 duration.
+// The components must use the given designators in order. The fraction is
+// allowed in the last component only when the part is the last one.
+func isISO8601DurationPart(str, designators string, last bool) bool {
+	prev := -1
+	for str != "" {
+		i := 0
+		for i < len(str) && isDigit(str[i]) {
+			i++
+		}
+		if i == 0 {
+			return false
+		}
+		frac := false
+		if i < len(str) && (str[i] == '.' || str[i] == ',') {
+			j := i + 1
+			for j < len(str) && isDigit(str[j]) {
+				j++
+			}
+			if j == i+1 {
+				return false
+			}
+			i, frac = j, true
+		}
+		if i == len(str) {
+			return false
+		}
+		idx := strings.IndexByte(designators, str[i])
+		if idx <= prev {
+			return false
+		}
+		prev, str = idx, str[i+1:]
+		if frac && (str != "" || !last) {
+			return false
+		}
+	}
+	return true
+}
+
+// DurationRange returns a rule that checks a duration is in the given
+// inclusive range. The value may be a [time.Duration] or a string accepted by
+// [time.ParseDuration]. The errors for values out of range have the same
+// messages and code as the [verax.Min] and [verax.Max] rules. The [ErrDuration]
+// is returned for strings which are not valid durations.
+//
+// Example:
+//
+//	rule := DurationRange(1*time.Second, 1*time.Hour)
+func DurationRange(minimum, maximum time.Duration) DurationRangeRule {
+	return DurationRangeRule{
+		minimum:   minimum,
+		maximum:   maximum,
+		condition: true,
+	}
+}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[DurationRangeRule]  = DurationRangeRule{}
+	_ verax.Conditioner[DurationRangeRule] = DurationRangeRule{}
+)
+
+// DurationRangeRule is a rule that checks a duration is in the given range.
+type DurationRangeRule struct {
+	minimum   time.Duration // Minimum duration.
+	maximum   time.Duration // Maximum duration.
+	condition bool          // Run validation only when true.
+	code      string        // Custom error code.
+	err       error         // Custom error.
+}
+
+// Validate checks if the given value is valid or not.
+func (r DurationRangeRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	if isNil, _ := verax.IsNil(v); isNil {
+		return nil
+	}
+	if verax.IsEmpty(v) {
+		return nil
+	}
+
+	val := verax.Indirect(v)
+	dur, ok := val.(time.Duration)
+	if !ok {
+		str, err := verax.EnsureString(val)
+		if err != nil {
+			return err
+		}
+		if dur, err = time.ParseDuration(str); err != nil {
+			return ruleError(ErrDuration, r.err, r.code)
+		}
+	}
+
+	var msg string
+	switch {
+	case dur < r.minimum:
+		msg = fmt.Sprintf("must be no less than %s", r.minimum)
+	case dur > r.maximum:
+		msg = fmt.Sprintf("must be no greater than %s", r.maximum)
+	default:
+		return nil
+	}
+	if r.err != nil {
+		return r.err
+	}
+	return xrr.New(msg, cmp.Or(r.code, verax.ECInvThreshold))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r DurationRangeRule) When(condition bool) DurationRangeRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r DurationRangeRule) Code(code string) DurationRangeRule {
+	r.code = code
+	r.err = setCode(r.err, code)
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r DurationRangeRule) Error(err error) DurationRangeRule {
+	r.err = err
+	return r
+}
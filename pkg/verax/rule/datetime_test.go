@@ -0,0 +1,446 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsRFC3339_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"UTC", "2006-01-02T15:04:05Z", true},
+		{"offset", "2006-01-02T15:04:05+07:00", true},
+		{"fraction", "2006-01-02T15:04:05.999Z", true},
+		{"date only", "2006-01-02", false},
+		{"no zone", "2006-01-02T15:04:05", false},
+		{"space separator", "2006-01-02 15:04:05Z", false},
+		{"invalid month", "2006-13-02T15:04:05Z", false},
+		{"invalid day", "2006-02-30T15:04:05Z", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsRFC3339(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_RFC3339(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("2006-01-02T15:04:05Z", RFC3339)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", RFC3339)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("2006-01-02", RFC3339)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be a valid RFC 3339 date and time (ECRFC3339)",
+			err,
+		)
+	})
+}
+
+func Test_DateLayout(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("2006-01-02", DateLayout(time.DateOnly))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", DateLayout(time.DateOnly))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("02/01/2006", DateLayout(time.DateOnly))
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be a valid date and time in the required format "+
+				"(ECDateLayout)",
+			err,
+		)
+	})
+
+	t.Run("error invalid date", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("2006-02-30", DateLayout(time.DateOnly))
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrDateLayout, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", DateLayout(time.Kitchen).Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertCode(t, "ECMy", err)
+	})
+}
+
+func Test_IsDuration_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"zero", "0", true},
+		{"seconds", "10s", true},
+		{"milliseconds", "300ms", true},
+		{"compound", "1h30m", true},
+		{"negative", "-1.5h", true},
+		{"no unit", "10", false},
+		{"invalid unit", "10d", false},
+		{"ISO 8601", "PT10S", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsDuration(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_Duration(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("1h30m", Duration)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("10d", Duration)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid duration (ECDuration)", err)
+	})
+}
+
+func Test_IsTimeZone_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"UTC", "UTC", true},
+		{"region", "Europe/Warsaw", true},
+		{"nested region", "America/Argentina/Buenos_Aires", true},
+		{"local", "Local", false},
+		{"lower case", "europe/warsaw", false},
+		{"unknown", "Europe/Nowhere", false},
+		{"offset", "+02:00", false},
+		{"path", "../etc/passwd", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsTimeZone(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_TimeZone(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("Europe/Warsaw", TimeZone)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("Europe/Nowhere", TimeZone)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid time zone (ECTimeZone)", err)
+	})
+}
+
+func Test_IsISO8601Duration_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"full", "P1Y2M10DT2H30M15S", true},
+		{"years", "P3Y", true},
+		{"months", "P6M", true},
+		{"weeks", "P2W", true},
+		{"days", "P10D", true},
+		{"hours", "PT2H", true},
+		{"minutes", "PT30M", true},
+		{"seconds", "PT15S", true},
+		{"zero", "PT0S", true},
+		{"fraction", "PT0.5S", true},
+		{"comma fraction", "PT0,5S", true},
+		{"fraction in date part", "P1.5D", true},
+		{"only P", "P", false},
+		{"only PT", "PT", false},
+		{"no P", "1Y", false},
+		{"lower case", "p1y", false},
+		{"minutes without T", "P1Y30S", false},
+		{"wrong order", "P1D2Y", false},
+		{"repeated designator", "P1Y1Y", false},
+		{"fraction not last", "PT0.5H30M", false},
+		{"fraction before time", "P1.5DT2H", false},
+		{"empty fraction", "PT1.S", false},
+		{"no number", "PTS", false},
+		{"number without designator", "P10", false},
+		{"unknown designator", "P1X", false},
+		{"negative", "-P1D", false},
+		{"double T", "PT1HT2M", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsISO8601Duration(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ISO8601Duration(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("P1DT12H", ISO8601Duration)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("P1H", ISO8601Duration)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be a valid ISO 8601 duration (ECISO8601Duration)",
+			err,
+		)
+	})
+}
+
+func Test_DurationRange(t *testing.T) {
+	t.Run("success string", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate("30m", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success duration", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate(30*time.Minute, rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success pointer", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+		dur := time.Minute
+
+		// --- When ---
+		err := verax.Validate(&dur, rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success boundaries", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		errMin := verax.Validate("1s", rule)
+		errMax := verax.Validate("1h", rule)
+
+		// --- Then ---
+		assert.NoError(t, errMin)
+		assert.NoError(t, errMax)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate("", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error too short", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate("0s", rule)
+
+		// --- Then ---
+		want := verax.Validate(time.Millisecond, verax.Min(time.Second))
+		assert.Equal(t, want.Error(), err.Error())
+		xrrtest.AssertEqual(t, "must be no less than 1s (ECInvThreshold)", err)
+	})
+
+	t.Run("error too long", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate(2*time.Hour, rule)
+
+		// --- Then ---
+		want := verax.Validate(2*time.Hour, verax.Max(time.Hour))
+		assert.Equal(t, want.Error(), err.Error())
+		xrrtest.AssertEqual(
+			t,
+			"must be no greater than 1h0m0s (ECInvThreshold)",
+			err,
+		)
+	})
+
+	t.Run("error invalid duration", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate("10d", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid duration (ECDuration)", err)
+	})
+
+	t.Run("error invalid type", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour)
+
+		// --- When ---
+		err := verax.Validate(10, rule)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour).When(false)
+
+		// --- When ---
+		err := verax.Validate("10d", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- Given ---
+		rule := DurationRange(time.Second, time.Hour).Code("ECMy")
+
+		// --- When ---
+		errRng := verax.Validate("2h", rule)
+		errDur := verax.Validate("10d", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be no greater than 1h0m0s (ECMy)", errRng)
+		xrrtest.AssertEqual(t, "must be a valid duration (ECMy)", errDur)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+		rule := DurationRange(time.Second, time.Hour).Error(my)
+
+		// --- When ---
+		errRng := verax.Validate("2h", rule)
+		errDur := verax.Validate("10d", rule)
+
+		// --- Then ---
+		assert.Same(t, my, errRng)
+		assert.Same(t, my, errDur)
+	})
+
+	t.Run("custom error and code", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+		rule := DurationRange(time.Second, time.Hour).Error(my).Code("ECMy")
+
+		// --- When ---
+		errRng := verax.Validate("2h", rule)
+		errDur := verax.Validate("10d", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "my error (ECMy)", errRng)
+		xrrtest.AssertEqual(t, "my error (ECMy)", errDur)
+	})
+}
@@ -0,0 +1,221 @@
+package rule
+
+import (
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// Email address length limits defined in RFC 5321.
+const (
+	// EmailMaxLength is the maximum length of an email address in octets.
+	EmailMaxLength = 254
+
+	// EmailMaxLocalLength is the maximum length of an email address local
+	// part in octets.
+	EmailMaxLocalLength = 64
+)
+
+// Validation errors.
+var (
+	// ErrEmail is the error that returns in case of an invalid email address.
+	ErrEmail = xrr.New("must be a valid email address", "ECEmail")
+
+	// ErrEmailDisplayName is the error that returns in case of an email
+	// address with a display name when display names are not allowed.
+	ErrEmailDisplayName = xrr.New(
+		"must be an email address without a display name",
+		"ECEmailDisplayName",
+	)
+
+	// ErrEmailLength is the error that returns in case of an email address or
+	// its local part exceeding the maximum length.
+	ErrEmailLength = xrr.New("email address is too long", "ECEmailLength")
+
+	// ErrEmailLocal is the error that returns in case of an email address with
+	// an invalid local part.
+	ErrEmailLocal = xrr.New(
+		"must be an email address with a valid local part",
+		"ECEmailLocal",
+	)
+
+	// ErrEmailDomain is the error that returns in case of an email address
+	// with an invalid domain.
+	ErrEmailDomain = xrr.New(
+		"must be an email address with a valid domain",
+		"ECEmailDomain",
+	)
+
+	// ErrEmailIPLiteral is the error that returns in case of an email address
+	// with an IP literal domain when IP literals are not allowed.
+	ErrEmailIPLiteral = xrr.New(
+		"must be an email address without an IP address domain",
+		"ECEmailIPLiteral",
+	)
+)
+
+// Email validates if a string is a valid email address. The address is
+// parsed using [mail.ParseAddress] (RFC 5322) and the domain must pass the
+// [IsDomain] check. By default, only ASCII local parts are allowed, display
+// names (e.g. "Bob <bob@example.com>") and IP literal domains (e.g.
+// "bob@[192.0.2.1]") are rejected, and the RFC 5321 length limits are
+// enforced. Use [EmailRule] methods to change the defaults.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrEmail] - the address cannot be parsed.
+//   - [ErrEmailDisplayName] - the address has a display name.
+//   - [ErrEmailLength] - the address or its local part is too long.
+//   - [ErrEmailLocal] - the local part is not valid.
+//   - [ErrEmailDomain] - the domain is not valid.
+//   - [ErrEmailIPLiteral] - the domain is an IP literal.
+var Email = EmailRule{maxLength: EmailMaxLength, condition: true}
+
+// IsEmail checks if a string is a valid email address using the [Email] rule
+// defaults.
+func IsEmail(str string) bool { return Email.check(str) == nil }
+
+// Compile time checks.
+var (
+	_ verax.Customizer[EmailRule]  = EmailRule{}
+	_ verax.Conditioner[EmailRule] = EmailRule{}
+)
+
+// EmailRule is a rule that checks a string is a valid email address.
+type EmailRule struct {
+	unicode     bool   // Allow non-ASCII local parts (RFC 6531).
+	displayName bool   // Allow display names.
+	ipLiteral   bool   // Allow IP literal domains.
+	maxLength   int    // Maximum address length, zero for no limit.
+	condition   bool   // Run validation only when true.
+	code        string // Custom error code.
+	err         error  // Custom error.
+}
+
+// AllowUnicode configures the rule to allow internationalized (non-ASCII)
+// local parts as defined in RFC 6531.
+func (r EmailRule) AllowUnicode() EmailRule {
+	r.unicode = true
+	return r
+}
+
+// AllowDisplayName configures the rule to allow addresses with display names
+// like "Bob <bob@example.com>".
+func (r EmailRule) AllowDisplayName() EmailRule {
+	r.displayName = true
+	return r
+}
+
+// AllowIPLiteral configures the rule to allow IP literal domains like
+// "bob@[192.0.2.1]" or "bob@[IPv6:2001:db8::1]".
+func (r EmailRule) AllowIPLiteral() EmailRule {
+	r.ipLiteral = true
+	return r
+}
+
+// MaxLength configures the maximum length of the address in octets. The
+// default is [EmailMaxLength]. Zero disables the address and local part
+// length checks.
+func (r EmailRule) MaxLength(n int) EmailRule {
+	r.maxLength = n
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r EmailRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r EmailRule) When(condition bool) EmailRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r EmailRule) Code(code string) EmailRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r EmailRule) Error(err error) EmailRule {
+	r.err = err
+	return r
+}
+
+// check returns an error describing why the string is not a valid email
+// address or nil if it is valid.
+func (r EmailRule) check(str string) error {
+	if str == "" || strings.TrimSpace(str) != str {
+		return ErrEmail
+	}
+	addr, err := mail.ParseAddress(str)
+	if err != nil {
+		return ErrEmail
+	}
+	if !r.displayName && (addr.Name != "" || strings.HasSuffix(str, ">")) {
+		return ErrEmailDisplayName
+	}
+
+	idx := strings.LastIndexByte(addr.Address, '@')
+	local, domain := addr.Address[:idx], addr.Address[idx+1:]
+	if r.maxLength > 0 &&
+		(len(addr.Address) > r.maxLength || len(local) > EmailMaxLocalLength) {
+
+		return ErrEmailLength
+	}
+	if !r.unicode && !isASCII(local) {
+		return ErrEmailLocal
+	}
+
+	if strings.HasPrefix(domain, "[") {
+		if !r.ipLiteral {
+			return ErrEmailIPLiteral
+		}
+		if !isIPLiteral(domain) {
+			return ErrEmailDomain
+		}
+		return nil
+	}
+	if !IsDomain(domain) {
+		return ErrEmailDomain
+	}
+	return nil
+}
+
+// isIPLiteral checks if the string is a valid address literal as defined in
+// RFC 5321 section 4.1.3, e.g. "[192.0.2.1]" or "[IPv6:2001:db8::1]".
+func isIPLiteral(str string) bool {
+	lit, ok := strings.CutPrefix(str, "[")
+	if !ok {
+		return false
+	}
+	if lit, ok = strings.CutSuffix(lit, "]"); !ok {
+		return false
+	}
+	if ip, ok := strings.CutPrefix(lit, "IPv6:"); ok {
+		return IsIPv6(ip)
+	}
+	return IsIPv4(lit)
+}
+
+// isASCII checks if the string contains only ASCII characters.
+func isASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
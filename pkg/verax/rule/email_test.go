@@ -0,0 +1,253 @@
+package rule
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsEmail_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		email string
+		want  bool
+	}{
+		{"empty", "", false},
+		{"valid", "bob@example.com", true},
+		{"subdomain", "bob.smith@mail.example.com", true},
+		{"plus", "bob+tag@example.com", true},
+		{"quoted local part", `"bob smith"@example.com`, true},
+		{"display name", "Bob <bob@example.com>", false},
+		{"angle address", "<bob@example.com>", false},
+		{"comment", "bob@example.com (Bob)", false},
+		{"unicode local part", "jörg@example.com", false},
+		{"unicode domain", "bob@münchen.de", false},
+		{"ip literal", "bob@[192.0.2.1]", false},
+		{"no domain", "bob@", false},
+		{"no at", "example.com", false},
+		{"two at", "bob@example@example.com", false},
+		{"double dot", "bob..smith@example.com", false},
+		{"domain without tld", "bob@localhost", false},
+		{"domain with leading dash", "bob@-example.com", false},
+		{"leading space", " bob@example.com", false},
+		{"trailing space", "bob@example.com ", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsEmail(tc.email)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_Email(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("bob@example.com", Email)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", Email)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when nil", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate((*string)(nil), Email)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success pointer", func(t *testing.T) {
+		// --- Given ---
+		email := "bob@example.com"
+
+		// --- When ---
+		err := verax.Validate(&email, Email)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error not a string", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(123, Email)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("invalid", Email.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("invalid", Email.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid email address (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("Bob <bob@example.com>", Email.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_Email_errors_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		email string
+		want  error
+	}{
+		{"not parsable", "example.com", ErrEmail},
+		{"leading space", " bob@example.com", ErrEmail},
+		{"display name", "Bob <bob@example.com>", ErrEmailDisplayName},
+		{"angle address", "<bob@example.com>", ErrEmailDisplayName},
+		{
+			"address too long",
+			"bob@" + strings.Repeat("a", 250) + ".com",
+			ErrEmailLength,
+		},
+		{
+			"local part too long",
+			strings.Repeat("a", 65) + "@example.com",
+			ErrEmailLength,
+		},
+		{"unicode local part", "jörg@example.com", ErrEmailLocal},
+		{"invalid domain", "bob@localhost", ErrEmailDomain},
+		{"ip literal", "bob@[192.0.2.1]", ErrEmailIPLiteral},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.email, Email)
+
+			// --- Then ---
+			assert.ErrorIs(t, tc.want, err)
+		})
+	}
+}
+
+func Test_EmailRule_AllowUnicode(t *testing.T) {
+	t.Run("unicode local part", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("jörg@example.com", Email.AllowUnicode())
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("unicode domain", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("bob@münchen.de", Email.AllowUnicode())
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrEmailDomain, err)
+	})
+}
+
+func Test_EmailRule_AllowDisplayName(t *testing.T) {
+	t.Run("display name", func(t *testing.T) {
+		// --- Given ---
+		rule := Email.AllowDisplayName()
+
+		// --- When ---
+		err := verax.Validate("Bob <bob@example.com>", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		// --- Given ---
+		rule := Email.AllowDisplayName()
+
+		// --- When ---
+		err := verax.Validate("Bob <bob@localhost>", rule)
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrEmailDomain, err)
+	})
+}
+
+func Test_EmailRule_AllowIPLiteral_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		email string
+		want  error
+	}{
+		{"IPv4", "bob@[192.0.2.1]", nil},
+		{"IPv6", "bob@[IPv6:2001:db8::1]", nil},
+		{"IPv6 without tag", "bob@[2001:db8::1]", ErrEmail},
+		{"IPv4 with IPv6 tag", "bob@[IPv6:192.0.2.1]", ErrEmailDomain},
+		{"invalid IPv4", "bob@[192.0.2.256]", ErrEmail},
+		{"domain", "bob@example.com", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.email, Email.AllowIPLiteral())
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_EmailRule_MaxLength(t *testing.T) {
+	t.Run("custom limit", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("bob@example.com", Email.MaxLength(10))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "email address is too long (ECEmailLength)", err)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		// --- Given ---
+		email := strings.Repeat("a", 65) + "@example.com"
+
+		// --- When ---
+		err := verax.Validate(email, Email.MaxLength(0))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+}
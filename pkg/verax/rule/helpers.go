@@ -0,0 +1,78 @@
+package rule
+
+import (
+	"strings"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// validateString validates a string (or a byte slice) value using the check
+// function. Nil and empty values are considered valid.
+func validateString(v any, check func(str string) error) error {
+	if isNil, _ := verax.IsNil(v); isNil {
+		return nil
+	}
+	if verax.IsEmpty(v) {
+		return nil
+	}
+	str, err := verax.EnsureString(verax.Indirect(v))
+	if err != nil {
+		return err
+	}
+	return check(str)
+}
+
+// ruleError returns the custom error if not nil. Otherwise, it returns the
+// error with the code set to the custom code if not empty.
+func ruleError(err, custom error, code string) error {
+	if err == nil {
+		return nil
+	}
+	if custom != nil {
+		return custom
+	}
+	return setCode(err, code)
+}
+
+// setCode sets the error code on the error. It returns the error as is when
+// the code is empty or the same as the error code. It mirrors the helper used
+// by the [verax] package rules.
+func setCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	if code == "" {
+		return err
+	}
+	if have := xrr.GetCode(err); have == code {
+		return err
+	}
+	return xrr.Wrap(err, xrr.WithCode(code))
+}
+
+// ruleCheck returns the check function with its errors customized using
+// [ruleError]. Use it to customize errors of the value check only, so the
+// type errors are returned as is.
+func ruleCheck[T any](
+	check func(T) error,
+	custom error,
+	code string,
+) func(T) error {
+
+	return func(val T) error { return ruleError(check(val), custom, code) }
+}
+
+// removeChars returns the string with all the given characters removed.
+func removeChars(str, chars string) string {
+	if chars == "" {
+		return str
+	}
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(chars, r) {
+			return -1
+		}
+		return r
+	}, str)
+}
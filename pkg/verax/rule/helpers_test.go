@@ -0,0 +1,172 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_validateString(t *testing.T) {
+	check := func(str string) error {
+		if str == "abc" {
+			return nil
+		}
+		return ErrSemVer
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		// --- When ---
+		err := validateString("abc", check)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid byte slice", func(t *testing.T) {
+		// --- When ---
+		err := validateString([]byte("abc"), check)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("nil and empty are valid", func(t *testing.T) {
+		// --- Given ---
+		var ptr *string
+
+		// --- When ---
+		errNil := validateString(nil, check)
+		errPtr := validateString(ptr, check)
+		errEmpty := validateString("", check)
+
+		// --- Then ---
+		assert.NoError(t, errNil)
+		assert.NoError(t, errPtr)
+		assert.NoError(t, errEmpty)
+	})
+
+	t.Run("error from check", func(t *testing.T) {
+		// --- When ---
+		err := validateString("xyz", check)
+
+		// --- Then ---
+		assert.Same(t, ErrSemVer, err)
+	})
+
+	t.Run("error not a string", func(t *testing.T) {
+		// --- When ---
+		err := validateString(123, check)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+}
+
+func Test_ruleError(t *testing.T) {
+	t.Run("nil error", func(t *testing.T) {
+		// --- When ---
+		err := ruleError(nil, errors.New("my error"), "ECMy")
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := ruleError(ErrSemVer, my, "ECMy")
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := ruleError(ErrSemVer, nil, "ECMy")
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid semantic version (ECMy)", err)
+	})
+
+	t.Run("no customization", func(t *testing.T) {
+		// --- When ---
+		err := ruleError(ErrSemVer, nil, "")
+
+		// --- Then ---
+		assert.Same(t, ErrSemVer, err)
+	})
+}
+
+func Test_ruleCheck(t *testing.T) {
+	check := func(str string) error {
+		if str == "abc" {
+			return nil
+		}
+		return ErrSemVer
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		// --- Given ---
+		fn := ruleCheck(check, errors.New("my error"), "ECMy")
+
+		// --- When ---
+		err := fn("abc")
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("check error is customized", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+		fn := ruleCheck(check, my, "ECMy")
+
+		// --- When ---
+		err := fn("xyz")
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+
+	t.Run("type error is not customized", func(t *testing.T) {
+		// --- Given ---
+		fn := ruleCheck(check, errors.New("my error"), "ECMy")
+
+		// --- When ---
+		err := validateString(123, fn)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+}
+
+func Test_removeChars_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str   string
+		chars string
+		want  string
+	}{
+		{"no chars", "a b-c", "", "a b-c"},
+		{"remove", "a b-c", " -", "abc"},
+		{"remove all", " - ", " -", ""},
+		{"runes", "ą·b·c", "·", "ąbc"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := removeChars(tc.str, tc.chars)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package rule
+
+import (
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// Validation errors.
+var (
+	// ErrHostPort is the error that returns in case of a string which cannot
+	// be split into a host and a port.
+	ErrHostPort = xrr.New("must be a valid host and port", "ECHostPort")
+
+	// ErrHostPortHost is the error that returns in case of a "host:port"
+	// string with an invalid host.
+	ErrHostPortHost = xrr.New(
+		"must be a host and port with a valid host",
+		"ECHostPortHost",
+	)
+
+	// ErrHostPortPort is the error that returns in case of a "host:port"
+	// string with an invalid, missing or not allowed port.
+	ErrHostPortPort = xrr.New(
+		"must be a host and port with a valid port",
+		"ECHostPortPort",
+	)
+)
+
+// HostPort validates if a string is a valid "host:port" endpoint like
+// "db.internal:5432" or "[::1]:8080". The string is split using
+// [net.SplitHostPort], the host must pass the [IsHost] check, and the port
+// must pass the [IsPort] check. IPv6 hosts must be in square brackets. Use
+// [HostPortRule] methods to change the defaults.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrHostPort] - the string cannot be split into a host and a port.
+//   - [ErrHostPortHost] - the host is not valid.
+//   - [ErrHostPortPort] - the port is missing, not valid, or not allowed.
+var HostPort = HostPortRule{condition: true}
+
+// IsHostPort checks if a string is a valid "host:port" endpoint using the
+// [HostPort] rule defaults.
+func IsHostPort(str string) bool { return HostPort.check(str) == nil }
+
+// Compile time checks.
+var (
+	_ verax.Customizer[HostPortRule]  = HostPortRule{}
+	_ verax.Conditioner[HostPortRule] = HostPortRule{}
+)
+
+// HostPortRule is a rule that checks a string is a valid "host:port"
+// endpoint.
+type HostPortRule struct {
+	optionalPort bool     // The port is optional.
+	brackets     bool     // IPv6 hosts must be in square brackets.
+	ports        [][2]int // Allowed port ranges, all allowed when empty.
+	condition    bool     // Run validation only when true.
+	code         string   // Custom error code.
+	err          error    // Custom error.
+}
+
+// OptionalPort configures the rule to allow strings without a port, like
+// "db.internal" or "[::1]". Without the port, IPv6 hosts are also allowed
+// without square brackets (e.g. "::1"), use [HostPortRule.RequireBrackets]
+// to disallow them.
+func (r HostPortRule) OptionalPort() HostPortRule {
+	r.optionalPort = true
+	return r
+}
+
+// RequireBrackets configures the rule to require IPv6 hosts to be in square
+// brackets also when the port is not present.
+func (r HostPortRule) RequireBrackets() HostPortRule {
+	r.brackets = true
+	return r
+}
+
+// PortRange configures the rule to allow ports in the given inclusive range.
+// It may be called multiple times to allow multiple ranges.
+//
+// Example:
+//
+//	// Only unprivileged ports.
+//	rule := HostPort.PortRange(1024, 65535)
+func (r HostPortRule) PortRange(lo, hi int) HostPortRule {
+	ports := make([][2]int, len(r.ports), len(r.ports)+1)
+	copy(ports, r.ports)
+	r.ports = append(ports, [2]int{lo, hi})
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r HostPortRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r HostPortRule) When(condition bool) HostPortRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r HostPortRule) Code(code string) HostPortRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r HostPortRule) Error(err error) HostPortRule {
+	r.err = err
+	return r
+}
+
+// check returns an error describing why the string is not a valid endpoint
+// or nil if it is valid.
+func (r HostPortRule) check(str string) error {
+	if str == "" {
+		return ErrHostPort
+	}
+
+	host, port, err := net.SplitHostPort(str)
+	if err != nil {
+		if r.optionalPort {
+			return r.checkHost(str) // The whole string is the host.
+		}
+		var ae *net.AddrError
+		if errors.As(err, &ae) && ae.Err == "missing port in address" {
+			return ErrHostPortPort
+		}
+		return ErrHostPort
+	}
+
+	if strings.HasPrefix(str, "[") {
+		host = "[" + host + "]"
+	}
+	if err = r.checkHost(host); err != nil {
+		return err
+	}
+	return r.checkPort(port)
+}
+
+// checkHost checks the host. IPv6 hosts may be in square brackets.
+func (r HostPortRule) checkHost(host string) error {
+	if inner, ok := strings.CutPrefix(host, "["); ok {
+		if inner, ok = strings.CutSuffix(inner, "]"); !ok || !IsIPv6(inner) {
+			return ErrHostPortHost
+		}
+		return nil
+	}
+	if IsIPv6(host) {
+		if r.brackets {
+			return ErrHostPortHost
+		}
+		return nil
+	}
+	if !IsHost(host) {
+		return ErrHostPortHost
+	}
+	return nil
+}
+
+// checkPort checks the port is valid and in one of the allowed ranges.
+func (r HostPortRule) checkPort(port string) error {
+	if !IsPort(port) {
+		return ErrHostPortPort
+	}
+	if len(r.ports) == 0 {
+		return nil
+	}
+	num, _ := strconv.Atoi(port)
+	for _, rng := range r.ports {
+		if num >= rng[0] && num <= rng[1] {
+			return nil
+		}
+	}
+	return ErrHostPortPort
+}
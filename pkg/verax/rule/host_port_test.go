@@ -0,0 +1,213 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsHostPort_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		str  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"domain", "db.internal:5432", true},
+		{"host", "localhost:80", true},
+		{"IPv4", "127.0.0.1:8080", true},
+		{"IPv6", "[::1]:8080", true},
+		{"IPv4-mapped IPv6", "[::ffff:1.2.3.4]:8080", true},
+		{"no port", "db.internal", false},
+		{"empty port", "db.internal:", false},
+		{"port zero", "db.internal:0", false},
+		{"port too big", "db.internal:65536", false},
+		{"port not a number", "db.internal:http", false},
+		{"empty host", ":80", false},
+		{"invalid host", "-db-:80", false},
+		{"IPv6 not bracketed", "::1:8080", false},
+		{"IPv4 bracketed", "[127.0.0.1]:8080", false},
+		{"domain bracketed", "[db.internal]:8080", false},
+		{"not closed bracket", "[::1:8080", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsHostPort(tc.str)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_HostPort(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("db.internal:5432", HostPort)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", HostPort)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error not a string", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(123, HostPort)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("db.internal", HostPort.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("db.internal", HostPort.Code("ECMy"))
+
+		// --- Then ---
+		wMsg := "must be a host and port with a valid port (ECMy)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("db.internal", HostPort.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_HostPortRule_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule HostPortRule
+		str  string
+		want error
+	}{
+		{"not parsable", HostPort, "a:b:c", ErrHostPort},
+		{"missing port", HostPort, "db.internal", ErrHostPortPort},
+		{"invalid port", HostPort, "db.internal:99999", ErrHostPortPort},
+		{"invalid host", HostPort, "-db-:5432", ErrHostPortHost},
+		{"bracketed host", HostPort, "[db.internal]:5432", ErrHostPortHost},
+		{"optional port", HostPort.OptionalPort(), "db.internal", nil},
+		{"optional port IPv4", HostPort.OptionalPort(), "127.0.0.1", nil},
+		{"optional port IPv6", HostPort.OptionalPort(), "::1", nil},
+		{"optional port IPv6 bracketed", HostPort.OptionalPort(), "[::1]", nil},
+		{
+			"optional port with port",
+			HostPort.OptionalPort(),
+			"db.internal:5432",
+			nil,
+		},
+		{
+			"optional port invalid host",
+			HostPort.OptionalPort(),
+			"-db-",
+			ErrHostPortHost,
+		},
+		{
+			"optional port invalid port",
+			HostPort.OptionalPort(),
+			"db.internal:0",
+			ErrHostPortPort,
+		},
+		{
+			"optional port bracketed domain",
+			HostPort.OptionalPort(),
+			"[db.internal]",
+			ErrHostPortHost,
+		},
+		{
+			"require brackets",
+			HostPort.OptionalPort().RequireBrackets(),
+			"[::1]",
+			nil,
+		},
+		{
+			"require brackets not bracketed",
+			HostPort.OptionalPort().RequireBrackets(),
+			"::1",
+			ErrHostPortHost,
+		},
+		{
+			"require brackets IPv4",
+			HostPort.OptionalPort().RequireBrackets(),
+			"127.0.0.1",
+			nil,
+		},
+		{"port range", HostPort.PortRange(1024, 65535), "db:5432", nil},
+		{"port range lower", HostPort.PortRange(1024, 2048), "db:1024", nil},
+		{"port range upper", HostPort.PortRange(1024, 2048), "db:2048", nil},
+		{
+			"port out of range",
+			HostPort.PortRange(1024, 65535),
+			"db:80",
+			ErrHostPortPort,
+		},
+		{
+			"port in second range",
+			HostPort.PortRange(80, 80).PortRange(443, 443),
+			"db:443",
+			nil,
+		},
+		{
+			"port not in ranges",
+			HostPort.PortRange(80, 80).PortRange(443, 443),
+			"db:8080",
+			ErrHostPortPort,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.str, tc.rule)
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_HostPortRule_PortRange(t *testing.T) {
+	// --- Given ---
+	base := HostPort.PortRange(80, 80)
+
+	// --- When ---
+	r0 := base.PortRange(443, 443)
+	r1 := base.PortRange(8080, 8080)
+
+	// --- Then ---
+	assert.Equal(t, [][2]int{{80, 80}}, base.ports)
+	assert.Equal(t, [][2]int{{80, 80}, {443, 443}}, r0.ports)
+	assert.Equal(t, [][2]int{{80, 80}, {8080, 8080}}, r1.ports)
+}
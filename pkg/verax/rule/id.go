@@ -0,0 +1,343 @@
+package rule
+
+import (
+	"encoding/hex"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// UUIDForm represents a bit set of accepted UUID string forms.
+type UUIDForm int
+
+// UUID string forms.
+const (
+	// UUIDCanonical represents the canonical UUID form, for example
+	// "f47ac10b-58cc-4372-a567-0e02b2c3d479".
+	UUIDCanonical UUIDForm = 1 << iota
+
+	// UUIDURN represents the URN UUID form, for example
+	// "urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479".
+	UUIDURN
+
+	// UUIDBraced represents the braced UUID form, for example
+	// "{f47ac10b-58cc-4372-a567-0e02b2c3d479}".
+	UUIDBraced
+)
+
+// Identifier alphabets and limits.
+const (
+	// crockford represents lower case letters of the Crockford's base32
+	// alphabet.
+	crockford = "abcdefghjkmnpqrstvwxyz"
+
+	// ksuidMax is the string representation of the largest KSUID.
+	ksuidMax = "aWgEPTl1tmebfsQzFP4bxwgy80V"
+)
+
+// Validation errors.
+var (
+	// ErrUUID is the error that returns in case of an invalid UUID.
+	ErrUUID = xrr.New("must be a valid UUID", "ECUUID")
+
+	// ErrUUIDVersion is the error that returns in case of a UUID of not
+	// allowed version.
+	ErrUUIDVersion = xrr.New(
+		"must be a UUID of an allowed version",
+		"ECUUIDVersion",
+	)
+
+	// ErrUUIDNil is the error that returns in case of the nil UUID when it is
+	// not allowed.
+	ErrUUIDNil = xrr.New("must not be the nil UUID", "ECUUIDNil")
+
+	// ErrULID is the error that returns in case of an invalid ULID.
+	ErrULID = xrr.New("must be a valid ULID", "ECULID")
+
+	// ErrKSUID is the error that returns in case of an invalid KSUID.
+	ErrKSUID = xrr.New("must be a valid KSUID", "ECKSUID")
+
+	// ErrInvID is the error that returns in case of an invalid identifier. It
+	// is the default error of the [IDRule].
+	ErrInvID = xrr.New("must be a valid identifier", "ECInvID")
+)
+
+// IsUUID checks if a string is a UUID in the canonical form. Any version and
+// variant, including the nil UUID, is accepted.
+func IsUUID(str string) bool {
+	_, ok := parseUUID(str, UUIDCanonical)
+	return ok
+}
+
+// UUID validates if a string or a [16]byte array is a valid UUID. By default,
+// only the canonical string form is accepted, any version and variant,
+// including the nil UUID, is valid. Use [UUIDRule] methods to change the
+// defaults.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrUUID] - the string is not a UUID in one of the accepted forms.
+//   - [ErrUUIDVersion] - the UUID version is not allowed.
+//   - [ErrUUIDNil] - the UUID is the nil UUID.
+var UUID = UUIDRule{forms: UUIDCanonical, condition: true}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[UUIDRule]  = UUIDRule{}
+	_ verax.Conditioner[UUIDRule] = UUIDRule{}
+)
+
+// UUIDRule is a rule that checks a value is a valid UUID.
+type UUIDRule struct {
+	forms     UUIDForm // Accepted string forms.
+	versions  []int    // Allowed versions, all allowed when empty.
+	noNil     bool     // Reject the nil UUID.
+	condition bool     // Run validation only when true.
+	code      string   // Custom error code.
+	err       error    // Custom error.
+}
+
+// Forms configures the accepted UUID string forms.
+//
+// Example:
+//
+//	rule := UUID.Forms(UUIDCanonical | UUIDURN | UUIDBraced)
+func (r UUIDRule) Forms(forms UUIDForm) UUIDRule {
+	r.forms = forms
+	return r
+}
+
+// Versions configures the rule to allow only the given UUID versions (1-8).
+// The UUID must also have the variant defined in RFC 9562. The nil UUID is
+// not affected by this constraint, use [UUIDRule.NoNil] to reject it.
+func (r UUIDRule) Versions(versions ...int) UUIDRule {
+	r.versions = versions
+	return r
+}
+
+// NoNil configures the rule to reject the nil UUID.
+func (r UUIDRule) NoNil() UUIDRule {
+	r.noNil = true
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r UUIDRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	str := ruleCheck(r.checkString, r.err, r.code)
+	bin := ruleCheck(r.checkBytes, r.err, r.code)
+	return validateID(v, 16, str, bin)
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r UUIDRule) When(condition bool) UUIDRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r UUIDRule) Code(code string) UUIDRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r UUIDRule) Error(err error) UUIDRule {
+	r.err = err
+	return r
+}
+
+// checkString checks the UUID string.
+func (r UUIDRule) checkString(str string) error {
+	uuid, ok := parseUUID(str, r.forms)
+	if !ok {
+		return ErrUUID
+	}
+	return r.checkBytes(uuid[:])
+}
+
+// checkBytes checks the UUID bytes.
+func (r UUIDRule) checkBytes(uuid []byte) error {
+	if !slices.ContainsFunc(uuid, func(b byte) bool { return b != 0 }) {
+		if r.noNil {
+			return ErrUUIDNil
+		}
+		return nil
+	}
+	if len(r.versions) == 0 {
+		return nil
+	}
+	ver := int(uuid[6] >> 4)
+	if uuid[8]&0xc0 != 0x80 || !slices.Contains(r.versions, ver) {
+		return ErrUUIDVersion
+	}
+	return nil
+}
+
+// parseUUID parses UUID string in one of the given forms.
+func parseUUID(str string, forms UUIDForm) ([16]byte, bool) {
+	var uuid [16]byte
+	switch {
+	case len(str) == 36 && forms&UUIDCanonical != 0:
+	case len(str) == 45 && forms&UUIDURN != 0 &&
+		strings.EqualFold(str[:9], "urn:uuid:"):
+
+		str = str[9:]
+
+	case len(str) == 38 && forms&UUIDBraced != 0 &&
+		str[0] == '{' && str[37] == '}':
+
+		str = str[1:37]
+
+	default:
+		return uuid, false
+	}
+
+	if str[8] != '-' || str[13] != '-' || str[18] != '-' || str[23] != '-' {
+		return uuid, false
+	}
+	src := str[:8] + str[9:13] + str[14:18] + str[19:23] + str[24:]
+	if _, err := hex.Decode(uuid[:], []byte(src)); err != nil {
+		return uuid, false
+	}
+	return uuid, true
+}
+
+// IsULID checks if a string is a valid ULID in its canonical 26 character
+// Crockford's base32 representation. Letters are case-insensitive.
+func IsULID(str string) bool {
+	if len(str) != 26 || str[0] > '7' {
+		return false // The first character above 7 overflows 128 bits.
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if c >= '0' && c <= '9' {
+			continue
+		}
+		if !strings.ContainsRune(crockford, rune(c|0x20)) {
+			return false
+		}
+	}
+	return true
+}
+
+// ULID validates if a string or a [16]byte array is a valid ULID.
+var ULID = IDString(IsULID, 16).Error(ErrULID)
+
+// IsKSUID checks if a string is a valid KSUID in its 27 character base62
+// representation.
+func IsKSUID(str string) bool {
+	if len(str) != 27 || str > ksuidMax {
+		return false
+	}
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		if (c < '0' || c > '9') && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// KSUID validates if a string or a [20]byte array is a valid KSUID.
+var KSUID = IDString(IsKSUID, 20).Error(ErrKSUID)
+
+// IDString returns a validation rule which checks strings using the given
+// function. Byte arrays of the given size are binary representations of
+// identifiers and are always valid. It is the same as [verax.String] but
+// also accepts binary identifiers.
+func IDString(fn verax.ValidStringFunc, size int) IDRule {
+	return IDRule{fn: fn, size: size, condition: true, err: ErrInvID}
+}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[IDRule]  = IDRule{}
+	_ verax.Conditioner[IDRule] = IDRule{}
+)
+
+// IDRule is a rule that checks a string or a byte array is a valid identifier.
+type IDRule struct {
+	fn        verax.ValidStringFunc // Validation function.
+	size      int                   // Size of the binary identifier.
+	condition bool                  // Run validation only when true.
+	err       error                 // Validation error.
+}
+
+// Validate checks if the given value is valid or not.
+func (r IDRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	str := func(str string) error {
+		if r.fn(str) {
+			return nil
+		}
+		return r.err
+	}
+	return validateID(v, r.size, str, func([]byte) error { return nil })
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r IDRule) When(condition bool) IDRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r IDRule) Code(code string) IDRule {
+	r.err = setCode(r.err, code)
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r IDRule) Error(err error) IDRule {
+	r.err = err
+	return r
+}
+
+// validateID validates the identifier value. Byte arrays of the given size
+// are checked using the bin function, strings and byte slices using the str
+// function. Nil and empty values are considered valid.
+func validateID(
+	v any,
+	size int,
+	str func(string) error,
+	bin func([]byte) error,
+) error {
+
+	if isNil, _ := verax.IsNil(v); isNil {
+		return nil
+	}
+	if verax.IsEmpty(v) {
+		return nil
+	}
+
+	val := verax.Indirect(v)
+	rv := reflect.ValueOf(val)
+	if rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+		if rv.Len() != size {
+			return xrr.New("must be a byte array of valid size", verax.ECInvType)
+		}
+		bs := make([]byte, size)
+		reflect.Copy(reflect.ValueOf(bs), rv)
+		return bin(bs)
+	}
+
+	s, err := verax.EnsureString(val)
+	if err != nil {
+		return err
+	}
+	return str(s)
+}
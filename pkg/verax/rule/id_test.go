@@ -0,0 +1,421 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// TUUID is a test type representing UUID stored as bytes.
+type TUUID [16]byte
+
+func Test_IsUUID_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		uuid string
+		want bool
+	}{
+		{"empty", "", false},
+		{"v4", "f47ac10b-58cc-4372-a567-0e02b2c3d479", true},
+		{"upper case", "F47AC10B-58CC-4372-A567-0E02B2C3D479", true},
+		{"nil", "00000000-0000-0000-0000-000000000000", true},
+		{"max", "ffffffff-ffff-ffff-ffff-ffffffffffff", true},
+		{"urn", "urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{"braced", "{f47ac10b-58cc-4372-a567-0e02b2c3d479}", false},
+		{"no dashes", "f47ac10b58cc4372a5670e02b2c3d479", false},
+		{"misplaced dash", "f47ac10b5-8cc-4372-a567-0e02b2c3d479", false},
+		{"not hex", "g47ac10b-58cc-4372-a567-0e02b2c3d479", false},
+		{"too short", "f47ac10b-58cc-4372-a567-0e02b2c3d47", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsUUID(tc.uuid)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_UUID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("f47ac10b-58cc-4372-a567-0e02b2c3d479", UUID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", UUID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success bytes", func(t *testing.T) {
+		// --- Given ---
+		uuid := [16]byte{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72}
+
+		// --- When ---
+		err := verax.Validate(uuid, UUID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success named bytes type", func(t *testing.T) {
+		// --- Given ---
+		uuid := TUUID{0xf4, 0x7a, 0xc1, 0x0b, 0x58, 0xcc, 0x43, 0x72}
+
+		// --- When ---
+		err := verax.Validate(&uuid, UUID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", UUID)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid UUID (ECUUID)", err)
+	})
+
+	t.Run("error invalid byte array size", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate([15]byte{1}, UUID)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+
+	t.Run("error not a string", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(123, UUID)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", UUID.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", UUID.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid UUID (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("abc", UUID.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_UUIDRule_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule UUIDRule
+		uuid any
+		want error
+	}{
+		{
+			"urn",
+			UUID.Forms(UUIDURN),
+			"urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			nil,
+		},
+		{
+			"urn upper case",
+			UUID.Forms(UUIDURN),
+			"URN:UUID:f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			nil,
+		},
+		{
+			"urn not accepted",
+			UUID,
+			"urn:uuid:f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			ErrUUID,
+		},
+		{
+			"canonical not accepted",
+			UUID.Forms(UUIDURN | UUIDBraced),
+			"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			ErrUUID,
+		},
+		{
+			"braced",
+			UUID.Forms(UUIDBraced),
+			"{f47ac10b-58cc-4372-a567-0e02b2c3d479}",
+			nil,
+		},
+		{
+			"braced not closed",
+			UUID.Forms(UUIDBraced),
+			"{f47ac10b-58cc-4372-a567-0e02b2c3d479)",
+			ErrUUID,
+		},
+		{
+			"version",
+			UUID.Versions(4, 7),
+			"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			nil,
+		},
+		{
+			"version 7",
+			UUID.Versions(4, 7),
+			"01890a5d-ac96-774b-bcce-b302099a8057",
+			nil,
+		},
+		{
+			"version not allowed",
+			UUID.Versions(7),
+			"f47ac10b-58cc-4372-a567-0e02b2c3d479",
+			ErrUUIDVersion,
+		},
+		{
+			"version with invalid variant",
+			UUID.Versions(4),
+			"f47ac10b-58cc-4372-c567-0e02b2c3d479",
+			ErrUUIDVersion,
+		},
+		{
+			"version of bytes",
+			UUID.Versions(4),
+			[16]byte{6: 0x40, 8: 0x80},
+			nil,
+		},
+		{
+			"version of bytes not allowed",
+			UUID.Versions(4),
+			[16]byte{6: 0x10, 8: 0x80},
+			ErrUUIDVersion,
+		},
+		{
+			"nil",
+			UUID.Versions(4),
+			"00000000-0000-0000-0000-000000000000",
+			nil,
+		},
+		{
+			"nil not allowed",
+			UUID.NoNil(),
+			"00000000-0000-0000-0000-000000000000",
+			ErrUUIDNil,
+		},
+		{
+			"nil bytes not allowed",
+			UUID.NoNil(),
+			[16]byte{},
+			ErrUUIDNil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.uuid, tc.rule)
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_IsULID_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		ulid string
+		want bool
+	}{
+		{"empty", "", false},
+		{"valid", "01ARZ3NDEKTSV4RRFFQ69G5FAV", true},
+		{"lower case", "01arz3ndektsv4rrffq69g5fav", true},
+		{"max", "7ZZZZZZZZZZZZZZZZZZZZZZZZZ", true},
+		{"overflow", "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+		{"letter I", "01ARZ3NDEKTSV4RRFFQ69G5FAI", false},
+		{"letter L", "01ARZ3NDEKTSV4RRFFQ69G5FAL", false},
+		{"letter O", "01ARZ3NDEKTSV4RRFFQ69G5FAO", false},
+		{"letter U", "01ARZ3NDEKTSV4RRFFQ69G5FAU", false},
+		{"too short", "01ARZ3NDEKTSV4RRFFQ69G5FA", false},
+		{"too long", "01ARZ3NDEKTSV4RRFFQ69G5FAVA", false},
+		{"not alphanumeric", "01ARZ3NDEKTSV4RRFFQ69G5FA-", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsULID(tc.ulid)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ULID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("01ARZ3NDEKTSV4RRFFQ69G5FAV", ULID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success bytes", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate([16]byte{1, 2, 3}, ULID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", ULID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", ULID)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid ULID (ECULID)", err)
+	})
+
+	t.Run("error invalid byte array size", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate([20]byte{1}, ULID)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+}
+
+func Test_IsKSUID_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		ksuid string
+		want  bool
+	}{
+		{"empty", "", false},
+		{"valid", "0ujtsYcgvSTl8PAuAdqWYSMnLOv", true},
+		{"min", "000000000000000000000000000", true},
+		{"max", "aWgEPTl1tmebfsQzFP4bxwgy80V", true},
+		{"overflow", "aWgEPTl1tmebfsQzFP4bxwgy80W", false},
+		{"too short", "0ujtsYcgvSTl8PAuAdqWYSMnLO", false},
+		{"too long", "0ujtsYcgvSTl8PAuAdqWYSMnLOvA", false},
+		{"not alphanumeric", "0ujtsYcgvSTl8PAuAdqWYSMnLO-", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsKSUID(tc.ksuid)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_KSUID(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("0ujtsYcgvSTl8PAuAdqWYSMnLOv", KSUID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success bytes", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate([20]byte{1, 2, 3}, KSUID)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", KSUID)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid KSUID (ECKSUID)", err)
+	})
+}
+
+func Test_IDString(t *testing.T) {
+	t.Run("default error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", IDString(IsULID, 16))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid identifier (ECInvID)", err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", ULID.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid ULID (ECMy)", err)
+	})
+
+	t.Run("empty code keeps error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", ULID.Code(""))
+
+		// --- Then ---
+		assert.Same(t, ErrULID, err)
+	})
+
+	t.Run("same code keeps error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", ULID.Code("ECULID"))
+
+		// --- Then ---
+		assert.Same(t, ErrULID, err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", ULID.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+}
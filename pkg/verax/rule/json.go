@@ -0,0 +1,185 @@
+package rule
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// Validation errors.
+var (
+	// ErrJSON is the error that returns in case of an invalid JSON document.
+	ErrJSON = xrr.New("must be a valid JSON", "ECJSON")
+
+	// ErrJSONObject is the error that returns in case of a JSON document which
+	// is not an object.
+	ErrJSONObject = xrr.New("must be a JSON object", "ECJSONObject")
+
+	// ErrJSONArray is the error that returns in case of a JSON document which
+	// is not an array.
+	ErrJSONArray = xrr.New("must be a JSON array", "ECJSONArray")
+
+	// ErrJSONType is the error that returns in case of a JSON document which
+	// structure does not match the rules given to [JSONWith], e.g. an array
+	// validated with the [verax.Map] rule.
+	ErrJSONType = xrr.New(
+		"must be a JSON document of valid structure",
+		"ECJSONType",
+	)
+)
+
+// IsJSON checks if a string is a valid JSON document.
+func IsJSON(str string) bool { return json.Valid([]byte(str)) }
+
+// IsJSONObject checks if a string is a valid JSON document with an object at
+// the top level.
+func IsJSONObject(str string) bool {
+	return JSONObject.check([]byte(str)) == nil
+}
+
+// IsJSONArray checks if a string is a valid JSON document with an array at
+// the top level.
+func IsJSONArray(str string) bool {
+	return JSONArray.check([]byte(str)) == nil
+}
+
+// JSON validates if a string, a byte slice, or a [json.RawMessage] is a valid
+// JSON document.
+var JSON = JSONRule{condition: true}
+
+// JSONObject validates if a string, a byte slice, or a [json.RawMessage] is a
+// valid JSON document with an object at the top level.
+var JSONObject = JSONRule{shape: '{', condition: true}
+
+// JSONArray validates if a string, a byte slice, or a [json.RawMessage] is a
+// valid JSON document with an array at the top level.
+var JSONArray = JSONRule{shape: '[', condition: true}
+
+// JSONWith returns a rule which decodes a string, a byte slice, or a
+// [json.RawMessage] JSON document and validates the result with the given
+// rules. The document is decoded to "any", so objects are validated as
+// map[string]any, arrays as []any, and numbers as float64. The errors
+// returned by the rules are returned as is, so errors of nested fields (e.g.
+// from the [verax.Map] rule) are reported under the validated field.
+//
+// The structure of the decoded document depends on the input, so when the
+// top-level [verax.Map] rule gets a JSON document which is not an object (e.g.
+// an array or null), the [ErrJSONType] is returned instead of the
+// [verax.ErrNotMapPtr]. All the other errors with the [verax.ECInternal] code
+// are returned as is, since they are caused by misconfigured rules.
+//
+// Example:
+//
+//	rule := JSONWith(
+//	    verax.Map(
+//	        verax.Key("name", verax.Required),
+//	        verax.Key("port", verax.Min(1.0), verax.Max(65535.0)),
+//	    ),
+//	)
+func JSONWith(rules ...verax.Rule) JSONRule {
+	return JSONRule{rules: rules, condition: true}
+}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[JSONRule]  = JSONRule{}
+	_ verax.Conditioner[JSONRule] = JSONRule{}
+)
+
+// JSONRule is a rule that checks a value is a valid JSON document.
+type JSONRule struct {
+	shape     byte         // Required first character, any when zero.
+	rules     []verax.Rule // Rules for the decoded document.
+	condition bool         // Run validation only when true.
+	code      string       // Custom error code.
+	err       error        // Custom error.
+}
+
+// Validate checks if the given value is valid or not.
+func (r JSONRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	if isNil, _ := verax.IsNil(v); isNil {
+		return nil
+	}
+	if verax.IsEmpty(v) {
+		return nil
+	}
+
+	doc, err := jsonBytes(verax.Indirect(v))
+	if err != nil {
+		return err
+	}
+	if err = r.check(doc); err != nil {
+		return ruleError(err, r.err, r.code)
+	}
+	if len(r.rules) == 0 {
+		return nil
+	}
+	var val any
+	_ = json.Unmarshal(doc, &val) // The document was validated.
+	err = verax.Validate(val, r.rules...)
+	if errors.Is(err, verax.ErrNotMapPtr) {
+		return ruleError(ErrJSONType, r.err, r.code)
+	}
+	return err
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r JSONRule) When(condition bool) JSONRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule. It does not affect the errors
+// returned by the rules given to [JSONWith].
+func (r JSONRule) Code(code string) JSONRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. It does not affect the errors
+// returned by the rules given to [JSONWith].
+func (r JSONRule) Error(err error) JSONRule {
+	r.err = err
+	return r
+}
+
+// check returns an error if the document is not valid JSON of the required
+// shape.
+func (r JSONRule) check(doc []byte) error {
+	if !json.Valid(doc) {
+		return ErrJSON
+	}
+	if r.shape == 0 {
+		return nil
+	}
+	if doc = bytes.TrimLeft(doc, " \t\r\n"); doc[0] == r.shape {
+		return nil
+	}
+	if r.shape == '{' {
+		return ErrJSONObject
+	}
+	return ErrJSONArray
+}
+
+// jsonBytes returns the JSON document from a string or a byte slice,
+// including types based on them like [json.RawMessage].
+func jsonBytes(v any) ([]byte, error) {
+	rv := reflect.ValueOf(v)
+	switch {
+	case rv.Kind() == reflect.String:
+		return []byte(rv.String()), nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+		return rv.Bytes(), nil
+	}
+	return nil, xrr.New("must be either a string or byte slice", verax.ECInvType)
+}
@@ -0,0 +1,417 @@
+package rule
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsJSON_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		doc  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"object", `{"a": 1}`, true},
+		{"array", `[1, 2]`, true},
+		{"string", `"abc"`, true},
+		{"number", `42`, true},
+		{"null", `null`, true},
+		{"whitespace", " \n{}\t", true},
+		{"trailing comma", `{"a": 1,}`, false},
+		{"single quotes", `{'a': 1}`, false},
+		{"two documents", `{} {}`, false},
+		{"not closed", `{"a": 1`, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsJSON(tc.doc)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IsJSONObject_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		doc  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"object", `{"a": 1}`, true},
+		{"leading whitespace", " \n{}", true},
+		{"array", `[{}]`, false},
+		{"string", `"{}"`, false},
+		{"invalid", `{"a"}`, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsJSONObject(tc.doc)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IsJSONArray_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		doc  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"array", `[1, 2]`, true},
+		{"leading whitespace", "\t[]", true},
+		{"object", `{"a": []}`, false},
+		{"null", `null`, false},
+		{"invalid", `[1,]`, false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsJSONArray(tc.doc)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_JSON(t *testing.T) {
+	t.Run("success string", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(`{"a": 1}`, JSON)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success bytes", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate([]byte(`[1, 2]`), JSON)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success raw message", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(json.RawMessage(`"abc"`), JSON)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success raw message pointer", func(t *testing.T) {
+		// --- Given ---
+		msg := json.RawMessage(`42`)
+
+		// --- When ---
+		err := verax.Validate(&msg, JSON)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		errStr := verax.Validate("", JSON)
+		errRaw := verax.Validate(json.RawMessage(nil), JSON)
+
+		// --- Then ---
+		assert.NoError(t, errStr)
+		assert.NoError(t, errRaw)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(json.RawMessage(`{"a": 1,}`), JSON)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid JSON (ECJSON)", err)
+	})
+
+	t.Run("error invalid type", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(42, JSON)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be either a string or byte slice (ECInvType)",
+			err,
+		)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("{", JSON.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("{", JSON.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid JSON (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("{", JSON.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_JSONObject(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(json.RawMessage(`{"a": 1}`), JSONObject)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error not object", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(`[1]`, JSONObject)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a JSON object (ECJSONObject)", err)
+	})
+
+	t.Run("error invalid", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(`{`, JSONObject)
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrJSON, err)
+	})
+}
+
+func Test_JSONArray(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(`[1, 2]`, JSONArray)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error not array", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(`{"a": 1}`, JSONArray)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a JSON array (ECJSONArray)", err)
+	})
+}
+
+func Test_JSONWith(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(
+			verax.Map(
+				verax.Key("name", verax.Required),
+				verax.Key("port", verax.Min(1.0), verax.Max(65535.0)),
+			),
+		)
+
+		// --- When ---
+		err := verax.Validate(`{"name": "db", "port": 5432}`, rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Required)
+
+		// --- When ---
+		err := verax.Validate("", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error invalid JSON", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Required)
+
+		// --- When ---
+		err := verax.Validate(`{"name": }`, rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid JSON (ECJSON)", err)
+	})
+
+	t.Run("error from rule", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Length(3, 5))
+
+		// --- When ---
+		err := verax.Validate(`[1]`, rule)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvLength, err)
+	})
+
+	t.Run("nested errors", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(
+			verax.Map(
+				verax.Key("name", verax.Required),
+				verax.Key("port", verax.Min(1.0), verax.Max(65535.0)),
+			),
+		)
+
+		// --- When ---
+		err := verax.Validate(`{"name": "", "port": 70000}`, rule)
+
+		// --- Then ---
+		xrrtest.AssertFieldCnt(t, 2, err)
+		xrrtest.AssertFieldCode(t, "name", verax.ECRequired, err)
+		xrrtest.AssertFieldCode(t, "port", verax.ECInvThreshold, err)
+	})
+
+	t.Run("nested errors under struct field", func(t *testing.T) {
+		// --- Given ---
+		type Plugin struct {
+			Config json.RawMessage `json:"config"`
+		}
+		plugin := Plugin{Config: json.RawMessage(`{"port": 70000}`)}
+		rule := JSONWith(
+			verax.Map(
+				verax.Key("port", verax.Max(65535.0)),
+			).AllowUnknown(),
+		)
+
+		// --- When ---
+		err := verax.ValidateStruct(&plugin, verax.Field(&plugin.Config, rule))
+
+		// --- Then ---
+		err = xrr.Flatten(err)
+		xrrtest.AssertFieldCnt(t, 1, err)
+		xrrtest.AssertFieldCode(t, "config.port", verax.ECInvThreshold, err)
+	})
+
+	t.Run("error map rule with null", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Map(verax.Key("name", verax.Required)))
+
+		// --- When ---
+		err := verax.Validate(`null`, rule)
+
+		// --- Then ---
+		wMsg := "must be a JSON document of valid structure (ECJSONType)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("error map rule with array", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Map(verax.Key("name", verax.Required)))
+
+		// --- When ---
+		err := verax.Validate(`[1]`, rule)
+
+		// --- Then ---
+		wMsg := "must be a JSON document of valid structure (ECJSONType)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("error misconfigured rule", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(SemVerConstraint(">>1"))
+
+		// --- When ---
+		err := verax.Validate(`"1.2.3"`, rule)
+
+		// --- Then ---
+		assert.ErrorIs(t, verax.ErrInvSetup, err)
+	})
+
+	t.Run("error misconfigured map key type", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Map(verax.Key(1, verax.Required)))
+
+		// --- When ---
+		err := verax.Validate(`{"1": "a"}`, rule)
+
+		// --- Then ---
+		wMsg := "1: key not the correct type (ECInternal)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+
+	t.Run("error misconfigured map path", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Map(verax.Path("a..b", verax.Required)))
+
+		// --- When ---
+		err := verax.Validate(`{"a": {"b": 1}}`, rule)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInternal, err)
+	})
+
+	t.Run("error structure under struct field", func(t *testing.T) {
+		// --- Given ---
+		type Plugin struct {
+			Name   string          `json:"name"`
+			Config json.RawMessage `json:"config"`
+		}
+		plugin := Plugin{Config: json.RawMessage(`[1]`)}
+		rule := JSONWith(verax.Map(verax.Key("port", verax.Required)))
+
+		// --- When ---
+		err := verax.ValidateStruct(
+			&plugin,
+			verax.Field(&plugin.Name, verax.Required),
+			verax.Field(&plugin.Config, rule),
+		)
+
+		// --- Then ---
+		xrrtest.AssertFieldCnt(t, 2, err)
+		xrrtest.AssertFieldCode(t, "name", verax.ECRequired, err)
+		xrrtest.AssertFieldCode(t, "config", "ECJSONType", err)
+	})
+
+	t.Run("custom code does not affect rules", func(t *testing.T) {
+		// --- Given ---
+		rule := JSONWith(verax.Length(3, 5)).Code("ECMy")
+
+		// --- When ---
+		errRule := verax.Validate(`[1]`, rule)
+		errJSON := verax.Validate(`[1`, rule)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvLength, errRule)
+		xrrtest.AssertCode(t, "ECMy", errJSON)
+	})
+}
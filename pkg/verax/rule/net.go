@@ -2,6 +2,7 @@ package rule
 
 import (
 	"net"
+	"net/netip"
 	"regexp"
 	"strconv"
 	"strings"
@@ -33,6 +34,36 @@ var (
 	domainRxc = regexp.MustCompile(domainRx)
 )
 
+// nonPublicPrefixes represents special purpose address ranges which are not
+// covered by the [netip.Addr] classification methods.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),       // This network (RFC 1122).
+	netip.MustParsePrefix("100.64.0.0/10"),   // Carrier-grade NAT (RFC 6598).
+	netip.MustParsePrefix("192.0.0.0/24"),    // IETF protocols (RFC 6890).
+	netip.MustParsePrefix("192.0.2.0/24"),    // Documentation (RFC 5737).
+	netip.MustParsePrefix("192.88.99.0/24"),  // 6to4 relay anycast (RFC 7526).
+	netip.MustParsePrefix("198.18.0.0/15"),   // Benchmarking (RFC 2544).
+	netip.MustParsePrefix("198.51.100.0/24"), // Documentation (RFC 5737).
+	netip.MustParsePrefix("203.0.113.0/24"),  // Documentation (RFC 5737).
+	netip.MustParsePrefix("240.0.0.0/4"),     // Reserved (RFC 1112).
+	netip.MustParsePrefix("::/96"),           // IPv4-compatible (RFC 4291).
+	netip.MustParsePrefix("64:ff9b:1::/48"),  // Local-use NAT64 (RFC 8215).
+	netip.MustParsePrefix("100::/64"),        // Discard-only (RFC 6666).
+	netip.MustParsePrefix("2001::/23"),       // IETF protocols (RFC 2928).
+	netip.MustParsePrefix("2001:db8::/32"),   // Documentation (RFC 3849).
+}
+
+// Prefixes of IPv6 addresses with an embedded IPv4 address.
+var (
+	// nat64Prefix represents the well-known NAT64 prefix (RFC 6052). The IPv4
+	// address is in the last four bytes.
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+
+	// sixToFourPrefix represents the 6to4 prefix (RFC 3056). The IPv4
+	// address is in the bytes following the prefix.
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
 // Validation errors.
 var (
 	// ErrIP is the error that returns in case of an invalid IPv4 or IPv6
@@ -56,6 +87,27 @@ var (
 
 	// ErrHost is the error that returns in case of an invalid network hostname.
 	ErrHost = xrr.New("must be a valid network hostname", "ECHost")
+
+	// ErrCIDR is the error that returns in case of an invalid CIDR notation.
+	ErrCIDR = xrr.New("must be a valid CIDR notation", "ECCIDR")
+
+	// ErrPublicIP is the error that returns in case of an IP address which is
+	// not a public one.
+	ErrPublicIP = xrr.New("must be a public IP address", "ECPublicIP")
+
+	// ErrIPNotInPrefixes is the error that returns in case of an IP address
+	// not in any of the allowed prefixes.
+	ErrIPNotInPrefixes = xrr.New(
+		"must be an IP address in the allowed ranges",
+		"ECIPNotInPrefixes",
+	)
+
+	// ErrIPInPrefixes is the error that returns in case of an IP address in
+	// one of the disallowed prefixes.
+	ErrIPInPrefixes = xrr.New(
+		"must be an IP address outside of the disallowed ranges",
+		"ECIPInPrefixes",
+	)
 )
 
 // IsIP checks if a string is either IPv4 or IPv6.
@@ -64,19 +116,21 @@ func IsIP(str string) bool { return net.ParseIP(str) != nil }
 // IP validates if a string is a valid IPv4 or IPv6 address.
 var IP = verax.String(IsIP).Error(ErrIP)
 
-// IsIPv4 checks if the string is IP version 4.
+// IsIPv4 checks if the string is IP version 4. IPv4-mapped IPv6 addresses
+// (e.g. "::ffff:1.2.3.4") are IP version 6 addresses.
 func IsIPv4(str string) bool {
-	ip := net.ParseIP(str)
-	return ip != nil && strings.Contains(str, ".")
+	addr, err := netip.ParseAddr(str)
+	return err == nil && addr.Is4()
 }
 
 // IPv4 validates if a string is a valid IPv4 address.
 var IPv4 = verax.String(IsIPv4).Error(ErrIPv4)
 
-// IsIPv6 checks if the string is IP version 6.
+// IsIPv6 checks if the string is IP version 6, including IPv4-mapped IPv6
+// addresses (e.g. "::ffff:1.2.3.4"). Addresses with a zone are not valid.
 func IsIPv6(str string) bool {
-	ip := net.ParseIP(str)
-	return ip != nil && strings.Contains(str, ":")
+	addr, err := netip.ParseAddr(str)
+	return err == nil && addr.Is6() && addr.Zone() == ""
 }
 
 // IPv6 validates if a string is a valid IPv6 address.
@@ -120,3 +174,107 @@ func IsHost(str string) bool { return IsIP(str) || IsDNSName(str) }
 
 // Host validates if a string is a valid network hostname.
 var Host = verax.String(IsHost).Error(ErrHost)
+
+// IsCIDR checks if the string is a valid IPv4 or IPv6 CIDR notation, e.g.
+// "192.0.2.0/24" or "2001:db8::/32".
+func IsCIDR(str string) bool {
+	_, err := netip.ParsePrefix(str)
+	return err == nil
+}
+
+// CIDR validates if a string is a valid CIDR notation.
+var CIDR = verax.String(IsCIDR).Error(ErrCIDR)
+
+// IsPublicIP checks if the string is a public IP address. Unspecified,
+// loopback, private, link-local, multicast, carrier-grade NAT, documentation,
+// benchmarking, reserved, and other special purpose addresses are not public.
+// IPv4-mapped IPv6 addresses are classified as IPv4 addresses. NAT64 and 6to4
+// addresses are classified by the IPv4 address they embed, while deprecated
+// IPv4-compatible IPv6 addresses are never public.
+func IsPublicIP(str string) bool {
+	addr, err := parseAddr(str)
+	if err != nil {
+		return false
+	}
+	return isPublicAddr(addr)
+}
+
+// PublicIP validates if a string is a public IP address. It helps to prevent
+// users from registering internal addresses, for example, as webhook targets.
+// See [IsPublicIP] for details.
+var PublicIP = verax.String(IsPublicIP).Error(ErrPublicIP)
+
+// IPInPrefixes returns a validation rule that checks if a string is an IP
+// address in any of the given prefixes. IPv4-mapped IPv6 addresses are
+// matched as IPv4 addresses. Strings which are not IP addresses are not
+// valid.
+func IPInPrefixes(prefixes ...netip.Prefix) verax.StringRule {
+	fn := func(str string) bool {
+		addr, err := parseAddr(str)
+		return err == nil && inPrefixes(addr, prefixes)
+	}
+	return verax.String(fn).Error(ErrIPNotInPrefixes)
+}
+
+// NotIPInPrefixes returns a validation rule that checks if a string is an IP
+// address not in any of the given prefixes. IPv4-mapped IPv6 addresses are
+// matched as IPv4 addresses. Strings which are not IP addresses are not
+// valid.
+func NotIPInPrefixes(prefixes ...netip.Prefix) verax.StringRule {
+	fn := func(str string) bool {
+		addr, err := parseAddr(str)
+		return err == nil && !inPrefixes(addr, prefixes)
+	}
+	return verax.String(fn).Error(ErrIPInPrefixes)
+}
+
+// parseAddr parses IP address without a zone. IPv4-mapped IPv6 addresses are
+// returned as IPv4 addresses.
+func parseAddr(str string) (netip.Addr, error) {
+	addr, err := netip.ParseAddr(str)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	if addr.Zone() != "" {
+		return netip.Addr{}, ErrIP
+	}
+	return addr.Unmap(), nil
+}
+
+// isPublicAddr checks if the address is a public IP address. See
+// [IsPublicIP] for details.
+func isPublicAddr(addr netip.Addr) bool {
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	if inPrefixes(addr, nonPublicPrefixes) {
+		return false
+	}
+	if v4, ok := embeddedIPv4(addr); ok {
+		return isPublicAddr(v4)
+	}
+	return true
+}
+
+// embeddedIPv4 returns the IPv4 address embedded in the NAT64 or 6to4 IPv6
+// address. It returns false for other addresses.
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	b := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[12:16])), true
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6])), true
+	}
+	return netip.Addr{}, false
+}
+
+// inPrefixes checks if the address is in any of the prefixes.
+func inPrefixes(addr netip.Addr, prefixes []netip.Prefix) bool {
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,11 @@
 package rule
 
 import (
+	"net/netip"
 	"testing"
 
 	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
 
 	"github.com/ctx42/verax/pkg/verax"
 )
@@ -79,6 +81,8 @@ func Test_IsIPv4_tabular(t *testing.T) {
 		{"IPv6 loopback", "::1", false},
 		{"IPv6", "1ce:c01d:bee2:15:a5:900d:a5:11fe", false},
 		{"IPv6 invalid", ":::1", false},
+		{"IPv4-mapped IPv6", "::ffff:1.2.3.4", false},
+		{"IPv6 with IPv4 notation", "64:ff9b::1.2.3.4", false},
 	}
 
 	for _, tc := range tt {
@@ -134,6 +138,8 @@ func Test_IsIPv6_tabular(t *testing.T) {
 		{"IPv6 loopback", "::1", true},
 		{"IPv6", "1ce:c01d:bee2:15:a5:900d:a5:11fe", true},
 		{"IPv6 invalid", ":::1", false},
+		{"IPv4-mapped IPv6", "::ffff:1.2.3.4", true},
+		{"IPv6 with zone", "fe80::1%eth0", false},
 	}
 
 	for _, tc := range tt {
@@ -443,3 +449,225 @@ func Test_Host(t *testing.T) {
 		assert.ErrorIs(t, ErrHost, err)
 	})
 }
+
+func Test_IsCIDR_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		cidr string
+		want bool
+	}{
+		{"empty", "", false},
+		{"IPv4", "192.0.2.0/24", true},
+		{"IPv4 host", "192.0.2.1/32", true},
+		{"IPv4 not masked", "192.0.2.1/24", true},
+		{"IPv6", "2001:db8::/32", true},
+		{"IPv4 invalid bits", "192.0.2.0/33", false},
+		{"IPv6 invalid bits", "2001:db8::/129", false},
+		{"no bits", "192.0.2.0", false},
+		{"invalid address", "192.0.2.256/24", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsCIDR(tc.cidr)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_CIDR(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("192.0.2.0/24", CIDR)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", CIDR)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("192.0.2.0", CIDR)
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrCIDR, err)
+	})
+}
+
+func Test_IsPublicIP_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		ip   string
+		want bool
+	}{
+		{"empty", "", false},
+		{"invalid", "256.0.0.0", false},
+		{"IPv4 public", "8.8.8.8", true},
+		{"IPv6 public", "2606:4700:4700::1111", true},
+		{"IPv4-mapped public", "::ffff:8.8.8.8", true},
+		{"IPv4 unspecified", "0.0.0.0", false},
+		{"IPv6 unspecified", "::", false},
+		{"IPv4 loopback", "127.0.0.1", false},
+		{"IPv6 loopback", "::1", false},
+		{"IPv4 private 10", "10.1.2.3", false},
+		{"IPv4 private 172", "172.16.0.1", false},
+		{"IPv4 private 192", "192.168.1.1", false},
+		{"IPv6 private", "fd00::1", false},
+		{"IPv4-mapped private", "::ffff:10.1.2.3", false},
+		{"IPv4-mapped loopback", "::ffff:127.0.0.1", false},
+		{"IPv4 link-local", "169.254.169.254", false},
+		{"IPv6 link-local", "fe80::1", false},
+		{"IPv6 link-local with zone", "fe80::1%eth0", false},
+		{"IPv4 CGNAT", "100.64.0.1", false},
+		{"IPv4 CGNAT upper", "100.127.255.254", false},
+		{"IPv4 after CGNAT", "100.128.0.1", true},
+		{"IPv4 documentation 1", "192.0.2.1", false},
+		{"IPv4 documentation 2", "198.51.100.1", false},
+		{"IPv4 documentation 3", "203.0.113.1", false},
+		{"IPv6 documentation", "2001:db8::1", false},
+		{"IPv4 multicast", "224.0.0.1", false},
+		{"IPv6 multicast", "ff02::1", false},
+		{"IPv4 broadcast", "255.255.255.255", false},
+		{"IPv4 this network", "0.1.2.3", false},
+		{"IPv4 IETF protocols", "192.0.0.1", false},
+		{"IPv6 IETF protocols", "2001::1", false},
+		{"IPv6 Teredo", "2001:0:4136:e378:8000:63bf:3fff:fdd2", false},
+		{"IPv6 ORCHIDv2", "2001:20::1", false},
+		{"IPv6 discard-only", "100::1", false},
+		{"IPv4 6to4 relay anycast", "192.88.99.1", false},
+		{"IPv4 benchmarking", "198.18.0.1", false},
+		{"IPv4 benchmarking upper", "198.19.255.254", false},
+		{"IPv4 after benchmarking", "198.20.0.1", true},
+		{"IPv4 reserved", "240.0.0.1", false},
+		{"IPv4-compatible loopback", "::7f00:1", false},
+		{"IPv4-compatible public", "::808:808", false},
+		{"NAT64 loopback", "64:ff9b::7f00:1", false},
+		{"NAT64 private", "64:ff9b::10.1.2.3", false},
+		{"NAT64 public", "64:ff9b::8.8.8.8", true},
+		{"NAT64 local-use", "64:ff9b:1::808:808", false},
+		{"6to4 loopback", "2002:7f00:1::", false},
+		{"6to4 private", "2002:c0a8:101::1", false},
+		{"6to4 public", "2002:808:808::1", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsPublicIP(tc.ip)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_PublicIP(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("8.8.8.8", PublicIP)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", PublicIP)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("127.0.0.1", PublicIP)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a public IP address (ECPublicIP)", err)
+	})
+}
+
+func Test_IPInPrefixes_tabular(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+
+	tt := []struct {
+		testN string
+
+		ip   string
+		want error
+	}{
+		{"IPv4 in prefix", "10.1.2.3", nil},
+		{"IPv6 in prefix", "2001:db8::1", nil},
+		{"IPv4-mapped in prefix", "::ffff:10.1.2.3", nil},
+		{"IPv4 not in prefix", "11.1.2.3", ErrIPNotInPrefixes},
+		{"IPv6 not in prefix", "2001:db9::1", ErrIPNotInPrefixes},
+		{"with zone", "2001:db8::1%eth0", ErrIPNotInPrefixes},
+		{"not an IP", "example.com", ErrIPNotInPrefixes},
+		{"empty", "", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.ip, IPInPrefixes(prefixes...))
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_NotIPInPrefixes_tabular(t *testing.T) {
+	prefixes := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+
+	tt := []struct {
+		testN string
+
+		ip   string
+		want error
+	}{
+		{"IPv4 not in prefix", "11.1.2.3", nil},
+		{"IPv6 not in prefix", "2001:db9::1", nil},
+		{"IPv4 in prefix", "10.1.2.3", ErrIPInPrefixes},
+		{"IPv6 in prefix", "2001:db8::1", ErrIPInPrefixes},
+		{"IPv4-mapped in prefix", "::ffff:10.1.2.3", ErrIPInPrefixes},
+		{"not an IP", "example.com", ErrIPInPrefixes},
+		{"empty", "", nil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.ip, NotIPInPrefixes(prefixes...))
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,314 @@
+package rule
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// ECPassword represents error code for a password not meeting requirements.
+const ECPassword = "ECPassword"
+
+// Password rule error metadata keys.
+const (
+	// PasswordRequired is the name of the error metadata key holding comma
+	// separated list of all the configured requirements.
+	PasswordRequired = "required"
+
+	// PasswordFailed is the name of the error metadata key holding comma
+	// separated list of the requirements which were not met.
+	PasswordFailed = "failed"
+
+	// PasswordMinLength is the name of the error metadata key holding the
+	// minimum password length in runes.
+	PasswordMinLength = "min_length"
+
+	// PasswordMaxRepeat is the name of the error metadata key holding the
+	// maximum number of repeated characters.
+	PasswordMaxRepeat = "max_repeat"
+
+	// PasswordMinEntropy is the name of the error metadata key holding the
+	// minimum entropy estimate in bits.
+	PasswordMinEntropy = "min_entropy"
+
+	// PasswordEntropy is the name of the error metadata key holding the
+	// entropy estimate of the validated password in bits.
+	PasswordEntropy = "entropy"
+)
+
+// Password requirement names used in the error metadata.
+const (
+	PassReqLength  = "length"  // Minimum length.
+	PassReqLower   = "lower"   // Lower case letter.
+	PassReqUpper   = "upper"   // Upper case letter.
+	PassReqDigit   = "digit"   // Digit.
+	PassReqSymbol  = "symbol"  // Symbol.
+	PassReqRepeat  = "repeat"  // Maximum repeated characters.
+	PassReqBanned  = "banned"  // No banned words.
+	PassReqEntropy = "entropy" // Minimum entropy.
+)
+
+// PasswordClass represents a bit set of password character classes.
+type PasswordClass int
+
+// Password character classes.
+const (
+	PassLower  PasswordClass = 1 << iota // Lower case letters.
+	PassUpper                            // Upper case letters.
+	PassDigit                            // Digits.
+	PassSymbol                           // Not letters nor digits.
+)
+
+// passwordClasses represents character classes with their requirement names
+// in the order they are reported.
+var passwordClasses = []struct {
+	class PasswordClass
+	name  string
+}{
+	{PassLower, PassReqLower},
+	{PassUpper, PassReqUpper},
+	{PassDigit, PassReqDigit},
+	{PassSymbol, PassReqSymbol},
+}
+
+// Password validates if a string is a password meeting the configured
+// requirements. By default, the password must be at least 8 runes long. Use
+// [PasswordRule] methods to configure the requirements.
+//
+// All the requirements are checked, and the error lists the requirements
+// which were not met in the [PasswordFailed] metadata key and all the
+// configured requirements in the [PasswordRequired] metadata key. The
+// requirement names are the PassReq* constants. The requirement parameters
+// are available under the [PasswordMinLength], [PasswordMaxRepeat], and
+// [PasswordMinEntropy] metadata keys.
+//
+// Example:
+//
+//	rule := Password.
+//	    MinLength(12).
+//	    Require(PassLower | PassUpper | PassDigit).
+//	    MaxRepeat(3).
+//	    Banned("password", "qwerty").
+//	    MinEntropy(60)
+var Password = PasswordRule{minLength: 8, condition: true, code: ECPassword}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[PasswordRule]  = PasswordRule{}
+	_ verax.Conditioner[PasswordRule] = PasswordRule{}
+)
+
+// PasswordRule is a rule that checks a string is a password meeting the
+// requirements.
+type PasswordRule struct {
+	minLength  int           // Minimum length in runes.
+	classes    PasswordClass // Required character classes.
+	maxRepeat  int           // Maximum repeated characters, zero for any.
+	banned     []string      // Lower case banned words.
+	minEntropy float64       // Minimum entropy in bits, zero for any.
+	condition  bool          // Run validation only when true.
+	code       string        // Error code.
+	err        error         // Custom error.
+}
+
+// MinLength configures the minimum password length in runes.
+func (r PasswordRule) MinLength(n int) PasswordRule {
+	r.minLength = n
+	return r
+}
+
+// Require configures the character classes the password must contain at
+// least one character of.
+func (r PasswordRule) Require(classes PasswordClass) PasswordRule {
+	r.classes = classes
+	return r
+}
+
+// MaxRepeat configures the maximum number of consecutive repeated characters,
+// e.g. with 2 the "aab" is valid, but "aaab" is not. Zero disables the check.
+func (r PasswordRule) MaxRepeat(n int) PasswordRule {
+	r.maxRepeat = n
+	return r
+}
+
+// Banned configures the words the password must not contain. The words are
+// matched case-insensitively anywhere in the password.
+func (r PasswordRule) Banned(words ...string) PasswordRule {
+	r.banned = make([]string, len(words))
+	for i, word := range words {
+		r.banned[i] = strings.ToLower(word)
+	}
+	return r
+}
+
+// MinEntropy configures the minimum password entropy estimate in bits. See
+// [PasswordEntropyOf] for details. Zero disables the check.
+func (r PasswordRule) MinEntropy(bits float64) PasswordRule {
+	r.minEntropy = bits
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r PasswordRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, r.check)
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r PasswordRule) When(condition bool) PasswordRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r PasswordRule) Code(code string) PasswordRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned with the
+// requirements metadata.
+func (r PasswordRule) Error(err error) PasswordRule {
+	r.err = err
+	return r
+}
+
+// check returns an error listing the requirements the password does not meet
+// or nil if it meets all of them.
+func (r PasswordRule) check(str string) error {
+	var required, failed []string
+	add := func(name string, ok bool) {
+		required = append(required, name)
+		if !ok {
+			failed = append(failed, name)
+		}
+	}
+
+	meta := xrr.Meta().Int(PasswordMinLength, r.minLength)
+	add(PassReqLength, utf8.RuneCountInString(str) >= r.minLength)
+
+	have := passwordClassesOf(str)
+	for _, pc := range passwordClasses {
+		if r.classes&pc.class != 0 {
+			add(pc.name, have&pc.class != 0)
+		}
+	}
+
+	if r.maxRepeat > 0 {
+		meta = meta.Int(PasswordMaxRepeat, r.maxRepeat)
+		add(PassReqRepeat, maxRepeated(str) <= r.maxRepeat)
+	}
+
+	if len(r.banned) > 0 {
+		lower := strings.ToLower(str)
+		ok := true
+		for _, word := range r.banned {
+			if word != "" && strings.Contains(lower, word) {
+				ok = false
+				break
+			}
+		}
+		add(PassReqBanned, ok)
+	}
+
+	if r.minEntropy > 0 {
+		bits := PasswordEntropyOf(str)
+		meta = meta.Float64(PasswordMinEntropy, r.minEntropy)
+		meta = meta.Float64(PasswordEntropy, bits)
+		add(PassReqEntropy, bits >= r.minEntropy)
+	}
+
+	if len(failed) == 0 {
+		return nil
+	}
+	lst := strings.Join(failed, ",")
+	meta = meta.Str(PasswordRequired, strings.Join(required, ","))
+	meta = meta.Str(PasswordFailed, lst)
+	if r.err != nil {
+		return xrr.Wrap(r.err, meta.Option())
+	}
+	msg := "must meet the password requirements, failed: " + lst
+	return xrr.New(msg, r.code, meta.Option())
+}
+
+// PasswordEntropyOf returns the password entropy estimate in bits. The
+// estimate is the number of runes multiplied by the base 2 logarithm of the
+// size of the character pool. The pool is the sum of the sizes of the
+// character classes used in the password: 26 for ASCII lower case letters, 26
+// for ASCII upper case letters, 10 for ASCII digits, 33 for ASCII symbols and
+// space, and 100 for all the other characters.
+func PasswordEntropyOf(str string) float64 {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range str {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r >= ' ' && r <= '~':
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	var pool int
+	for _, class := range []struct {
+		used bool
+		size int
+	}{
+		{lower, 26}, {upper, 26}, {digit, 10}, {symbol, 33}, {other, 100},
+	} {
+		if class.used {
+			pool += class.size
+		}
+	}
+	if pool == 0 {
+		return 0
+	}
+	return float64(utf8.RuneCountInString(str)) * math.Log2(float64(pool))
+}
+
+// passwordClassesOf returns the character classes used in the string.
+func passwordClassesOf(str string) PasswordClass {
+	var classes PasswordClass
+	for _, r := range str {
+		switch {
+		case unicode.IsLower(r):
+			classes |= PassLower
+		case unicode.IsUpper(r):
+			classes |= PassUpper
+		case unicode.IsDigit(r):
+			classes |= PassDigit
+		case !unicode.IsLetter(r):
+			classes |= PassSymbol
+		}
+	}
+	return classes
+}
+
+// maxRepeated returns the maximum number of consecutive repeated runes.
+func maxRepeated(str string) int {
+	var most, cnt int
+	prev := utf8.RuneError
+	for i, r := range str {
+		if i > 0 && r == prev {
+			cnt++
+		} else {
+			cnt = 1
+		}
+		prev, most = r, max(most, cnt)
+	}
+	return most
+}
@@ -0,0 +1,326 @@
+package rule
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_Password(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abcdefgh", Password)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", Password)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error too short", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abcdefg", Password)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must meet the password requirements, "+
+			"failed: length (ECPassword)", err)
+		xrrtest.AssertStr(t, PasswordRequired, "length", err)
+		xrrtest.AssertStr(t, PasswordFailed, "length", err)
+		xrrtest.AssertInt(t, PasswordMinLength, 8, err)
+		xrrtest.AssertKeyCnt(t, 3, err)
+	})
+
+	t.Run("length in runes", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("zażółćgę", Password)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error not string", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate(123, Password)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, verax.ECInvType, err)
+	})
+}
+
+func Test_PasswordRule_MinLength(t *testing.T) {
+	// --- Given ---
+	rule := Password.MinLength(4)
+
+	// --- When ---
+	errSuc := verax.Validate("abcd", rule)
+	errErr := verax.Validate("abc", rule)
+
+	// --- Then ---
+	assert.NoError(t, errSuc)
+	xrrtest.AssertStr(t, PasswordFailed, "length", errErr)
+	xrrtest.AssertInt(t, PasswordMinLength, 4, errErr)
+}
+
+func Test_PasswordRule_Require_tabular(t *testing.T) {
+	all := PassLower | PassUpper | PassDigit | PassSymbol
+
+	tt := []struct {
+		testN string
+
+		classes PasswordClass
+		pass    string
+		failed  string
+	}{
+		{"lower", PassLower, "ABCDEFGH", "lower"},
+		{"upper", PassUpper, "abcdefgh", "upper"},
+		{"digit", PassDigit, "abcdefgh", "digit"},
+		{"symbol", PassSymbol, "abcdefgh", "symbol"},
+		{"all missing", all, "        ", "lower,upper,digit"},
+		{"all and length", all, "aB1!", "length"},
+		{"unicode lower", PassLower, "ŻÓŁWIKIx", ""},
+		{"unicode upper", PassUpper, "żółwikiX", ""},
+		{"space is symbol", PassSymbol, "abcd efg", ""},
+		{"all present", all, "aB1!aB1!", ""},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- Given ---
+			rule := Password.Require(tc.classes)
+
+			// --- When ---
+			err := verax.Validate(tc.pass, rule)
+
+			// --- Then ---
+			if tc.failed == "" {
+				assert.NoError(t, err)
+				return
+			}
+			xrrtest.AssertStr(t, PasswordFailed, tc.failed, err)
+		})
+	}
+}
+
+func Test_PasswordRule_Require(t *testing.T) {
+	// --- Given ---
+	rule := Password.Require(PassUpper | PassDigit)
+
+	// --- When ---
+	err := verax.Validate("abcdefg", rule)
+
+	// --- Then ---
+	xrrtest.AssertEqual(t, "must meet the password requirements, "+
+		"failed: length,upper,digit (ECPassword)", err)
+	xrrtest.AssertStr(t, PasswordRequired, "length,upper,digit", err)
+}
+
+func Test_PasswordRule_MaxRepeat(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.MaxRepeat(2)
+
+		// --- When ---
+		err := verax.Validate("aabbccdd", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.MaxRepeat(2)
+
+		// --- When ---
+		err := verax.Validate("abcddd12", rule)
+
+		// --- Then ---
+		xrrtest.AssertStr(t, PasswordRequired, "length,repeat", err)
+		xrrtest.AssertStr(t, PasswordFailed, "repeat", err)
+		xrrtest.AssertInt(t, PasswordMaxRepeat, 2, err)
+	})
+
+	t.Run("runes", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.MaxRepeat(2)
+
+		// --- When ---
+		err := verax.Validate("abcdeććć", rule)
+
+		// --- Then ---
+		xrrtest.AssertStr(t, PasswordFailed, "repeat", err)
+	})
+}
+
+func Test_PasswordRule_Banned(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.Banned("password", "qwerty")
+
+		// --- When ---
+		err := verax.Validate("correct horse", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error case insensitive", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.Banned("password", "qwerty")
+
+		// --- When ---
+		err := verax.Validate("MyQWERTY123", rule)
+
+		// --- Then ---
+		xrrtest.AssertStr(t, PasswordRequired, "length,banned", err)
+		xrrtest.AssertStr(t, PasswordFailed, "banned", err)
+	})
+
+	t.Run("empty word is ignored", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.Banned("")
+
+		// --- When ---
+		err := verax.Validate("abcdefgh", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+}
+
+func Test_PasswordRule_MinEntropy(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.MinEntropy(45)
+
+		// --- When ---
+		err := verax.Validate("Abcdefg1", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.MinEntropy(45)
+
+		// --- When ---
+		err := verax.Validate("abcdefgh", rule)
+
+		// --- Then ---
+		xrrtest.AssertStr(t, PasswordRequired, "length,entropy", err)
+		xrrtest.AssertStr(t, PasswordFailed, "entropy", err)
+		xrrtest.AssertFloat64(t, PasswordMinEntropy, 45, err)
+		xrrtest.AssertFloat64(t, PasswordEntropy, 8*math.Log2(26), err)
+	})
+}
+
+func Test_PasswordRule_all_requirements(t *testing.T) {
+	// --- Given ---
+	rule := Password.
+		MinLength(12).
+		Require(PassLower | PassUpper | PassDigit | PassSymbol).
+		MaxRepeat(3).
+		Banned("password").
+		MinEntropy(70)
+
+	// --- When ---
+	err := verax.Validate("password1111", rule)
+
+	// --- Then ---
+	want := "length,lower,upper,digit,symbol,repeat,banned,entropy"
+	xrrtest.AssertStr(t, PasswordRequired, want, err)
+	want = "upper,symbol,repeat,banned,entropy"
+	xrrtest.AssertStr(t, PasswordFailed, want, err)
+	xrrtest.AssertInt(t, PasswordMinLength, 12, err)
+	xrrtest.AssertInt(t, PasswordMaxRepeat, 3, err)
+	xrrtest.AssertFloat64(t, PasswordMinEntropy, 70, err)
+	xrrtest.AssertFloat64(t, PasswordEntropy, 12*math.Log2(36), err)
+	xrrtest.AssertKeyCnt(t, 6, err)
+}
+
+func Test_PasswordRule_When(t *testing.T) {
+	t.Run("false", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.When(false)
+
+		// --- When ---
+		err := verax.Validate("abc", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("true", func(t *testing.T) {
+		// --- Given ---
+		rule := Password.When(true)
+
+		// --- When ---
+		err := verax.Validate("abc", rule)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, ECPassword, err)
+	})
+}
+
+func Test_PasswordRule_Code(t *testing.T) {
+	// --- Given ---
+	rule := Password.Code("MyCode")
+
+	// --- When ---
+	err := verax.Validate("abc", rule)
+
+	// --- Then ---
+	xrrtest.AssertCode(t, "MyCode", err)
+	xrrtest.AssertStr(t, PasswordFailed, "length", err)
+}
+
+func Test_PasswordRule_Error(t *testing.T) {
+	// --- Given ---
+	rule := Password.Error(errors.New("my error"))
+
+	// --- When ---
+	err := verax.Validate("abc", rule)
+
+	// --- Then ---
+	assert.ErrorEqual(t, "my error", err)
+	xrrtest.AssertStr(t, PasswordFailed, "length", err)
+	xrrtest.AssertInt(t, PasswordMinLength, 8, err)
+}
+
+func Test_PasswordEntropyOf_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		pass string
+		want float64
+	}{
+		{"empty", "", 0},
+		{"lower", "abcd", 4 * math.Log2(26)},
+		{"lower and upper", "abCD", 4 * math.Log2(52)},
+		{"digits", "1234", 4 * math.Log2(10)},
+		{"symbols", "!@ #", 4 * math.Log2(33)},
+		{"all ascii", "aB1!", 4 * math.Log2(95)},
+		{"other", "żółw", 4 * math.Log2(126)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := PasswordEntropyOf(tc.pass)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
@@ -0,0 +1,342 @@
+package rule
+
+import (
+	"regexp"
+	"slices"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// CardBrand represents a payment card brand.
+type CardBrand string
+
+// Payment card brands.
+const (
+	CardUnknown    CardBrand = ""           // Not recognized brand.
+	CardVisa       CardBrand = "visa"       // Visa.
+	CardMastercard CardBrand = "mastercard" // Mastercard.
+	CardAmex       CardBrand = "amex"       // American Express.
+	CardDiscover   CardBrand = "discover"   // Discover.
+	CardDiners     CardBrand = "diners"     // Diners Club.
+	CardJCB        CardBrand = "jcb"        // JCB.
+	CardUnionPay   CardBrand = "unionpay"   // UnionPay.
+	CardMaestro    CardBrand = "maestro"    // Maestro.
+)
+
+// cardIIN represents a range of Issuer Identification Numbers of a brand.
+type cardIIN struct {
+	brand  CardBrand // Card brand.
+	lo, hi int       // Inclusive range of IIN prefixes.
+	digits int       // Number of prefix digits.
+	minLen int       // Minimum card number length.
+	maxLen int       // Maximum card number length.
+}
+
+// cardIINs represents IIN ranges of supported card brands.
+var cardIINs = []cardIIN{
+	{CardAmex, 34, 34, 2, 15, 15},
+	{CardAmex, 37, 37, 2, 15, 15},
+	{CardDiners, 300, 305, 3, 14, 19},
+	{CardDiners, 36, 36, 2, 14, 19},
+	{CardDiners, 38, 39, 2, 14, 19},
+	{CardJCB, 3528, 3589, 4, 16, 19},
+	{CardVisa, 4, 4, 1, 13, 19},
+	{CardMastercard, 51, 55, 2, 16, 16},
+	{CardMastercard, 2221, 2720, 4, 16, 16},
+	{CardDiscover, 6011, 6011, 4, 16, 19},
+	{CardDiscover, 644, 649, 3, 16, 19},
+	{CardDiscover, 65, 65, 2, 16, 19},
+	{CardUnionPay, 62, 62, 2, 16, 19},
+	{CardMaestro, 50, 50, 2, 12, 19},
+	{CardMaestro, 56, 58, 2, 12, 19},
+	{CardMaestro, 6304, 6304, 4, 12, 19},
+	{CardMaestro, 6759, 6759, 4, 12, 19},
+	{CardMaestro, 6761, 6763, 4, 12, 19},
+}
+
+// ibanLengths represents IBAN lengths by country code.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BI": 27, "BR": 29, "BY": 28, "CH": 21, "CR": 22,
+	"CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28, "EE": 20,
+	"EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22,
+	"GE": 22, "GI": 23, "GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28,
+	"IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27, "JO": 30, "KW": 30,
+	"KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21,
+	"LY": 25, "MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27,
+	"MT": 31, "MU": 30, "NI": 28, "NL": 18, "NO": 15, "OM": 23, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27,
+	"SO": 23, "ST": 25, "SV": 28, "TL": 23, "TN": 24, "TR": 26, "UA": 29,
+	"VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// Regexp rules.
+const (
+	// bicRx represents valid BIC (ISO 9362) regular expression.
+	bicRx = `^[A-Z]{4}[A-Z]{2}[A-Z0-9]{2}([A-Z0-9]{3})?$`
+)
+
+// Compiled regexp rules.
+var (
+	// bicRxc represents compiled valid BIC regular expression.
+	bicRxc = regexp.MustCompile(bicRx)
+)
+
+// Validation errors.
+var (
+	// ErrCreditCard is the error that returns in case of an invalid payment
+	// card number.
+	ErrCreditCard = xrr.New("must be a valid card number", "ECCreditCard")
+
+	// ErrCardBrand is the error that returns in case of a payment card number
+	// of not allowed brand.
+	ErrCardBrand = xrr.New(
+		"must be a card number of an allowed brand",
+		"ECCardBrand",
+	)
+
+	// ErrIBAN is the error that returns in case of an invalid IBAN.
+	ErrIBAN = xrr.New("must be a valid IBAN", "ECIBAN")
+
+	// ErrBIC is the error that returns in case of an invalid BIC.
+	ErrBIC = xrr.New("must be a valid BIC", "ECBIC")
+)
+
+// IsCreditCard checks if a string is a valid payment card number. The number
+// must have from 12 to 19 digits without separators and pass the Luhn
+// checksum.
+func IsCreditCard(str string) bool { return CreditCard.check(str) == nil }
+
+// CreditCard validates if a string is a valid payment card number. By
+// default, separators are not allowed, and all the brands are accepted. Use
+// [CreditCardRule] methods to change the defaults.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrCreditCard] - the number is not valid.
+//   - [ErrCardBrand] - the card brand is not allowed.
+var CreditCard = CreditCardRule{condition: true}
+
+// CardBrandOf returns the brand of the payment card number based on its
+// Issuer Identification Number (IIN) and length. It returns [CardUnknown]
+// when the brand is not recognized. The number must not have separators and
+// is not validated.
+func CardBrandOf(number string) CardBrand {
+	for _, iin := range cardIINs {
+		if len(number) < iin.minLen || len(number) > iin.maxLen {
+			continue
+		}
+		prefix := 0
+		for i := 0; i < iin.digits; i++ {
+			prefix = prefix*10 + int(number[i]-'0')
+		}
+		if prefix >= iin.lo && prefix <= iin.hi {
+			return iin.brand
+		}
+	}
+	return CardUnknown
+}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[CreditCardRule]  = CreditCardRule{}
+	_ verax.Conditioner[CreditCardRule] = CreditCardRule{}
+)
+
+// CreditCardRule is a rule that checks a string is a valid payment card
+// number.
+type CreditCardRule struct {
+	separators string      // Allowed separator characters.
+	brands     []CardBrand // Allowed brands, all allowed when empty.
+	condition  bool        // Run validation only when true.
+	code       string      // Custom error code.
+	err        error       // Custom error.
+}
+
+// Separators configures the rule to allow the given separator characters
+// between the digits, for example, " -".
+func (r CreditCardRule) Separators(chars string) CreditCardRule {
+	r.separators = chars
+	return r
+}
+
+// Brands configures the rule to allow only card numbers of the given brands.
+// See [CardBrandOf] for details.
+func (r CreditCardRule) Brands(brands ...CardBrand) CreditCardRule {
+	r.brands = brands
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r CreditCardRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r CreditCardRule) When(condition bool) CreditCardRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r CreditCardRule) Code(code string) CreditCardRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r CreditCardRule) Error(err error) CreditCardRule {
+	r.err = err
+	return r
+}
+
+// check returns an error describing why the string is not a valid card
+// number or nil if it is valid.
+func (r CreditCardRule) check(str string) error {
+	if str == "" || !isDigit(str[0]) || !isDigit(str[len(str)-1]) {
+		return ErrCreditCard
+	}
+	num := removeChars(str, r.separators)
+	if len(num) < 12 || len(num) > 19 || !isDigits(num) || !luhn(num) {
+		return ErrCreditCard
+	}
+	if len(r.brands) > 0 && !slices.Contains(r.brands, CardBrandOf(num)) {
+		return ErrCardBrand
+	}
+	return nil
+}
+
+// IsIBAN checks if a string is a valid IBAN in the electronic format (upper
+// case, without separators). The length must match the country, and the
+// check digits must pass the ISO 7064 mod 97-10 check.
+func IsIBAN(str string) bool { return IBAN.check(str) == nil }
+
+// IBAN validates if a string is a valid International Bank Account Number.
+// By default, only the electronic format (upper case, without separators) is
+// accepted. Use [IBANRule.Separators] to accept the print format, e.g.
+// "DE89 3704 0044 0532 0130 00".
+var IBAN = IBANRule{condition: true}
+
+// Compile time checks.
+var (
+	_ verax.Customizer[IBANRule]  = IBANRule{}
+	_ verax.Conditioner[IBANRule] = IBANRule{}
+)
+
+// IBANRule is a rule that checks a string is a valid IBAN.
+type IBANRule struct {
+	separators string // Allowed separator characters.
+	condition  bool   // Run validation only when true.
+	code       string // Custom error code.
+	err        error  // Custom error.
+}
+
+// Separators configures the rule to allow the given separator characters
+// between the IBAN characters, for example, " ".
+func (r IBANRule) Separators(chars string) IBANRule {
+	r.separators = chars
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r IBANRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r IBANRule) When(condition bool) IBANRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r IBANRule) Code(code string) IBANRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r IBANRule) Error(err error) IBANRule {
+	r.err = err
+	return r
+}
+
+// check returns an error if the string is not a valid IBAN.
+func (r IBANRule) check(str string) error {
+	iban := removeChars(str, r.separators)
+	if len(iban) < 5 || ibanLengths[iban[:2]] != len(iban) {
+		return ErrIBAN
+	}
+	if !isDigit(iban[2]) || !isDigit(iban[3]) {
+		return ErrIBAN
+	}
+
+	// Move the country code and check digits to the end and compute the
+	// remainder of the number where letters are replaced by 10-35.
+	var rem int
+	for _, c := range []byte(iban[4:] + iban[:4]) {
+		switch {
+		case isDigit(c):
+			rem = (rem*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			rem = (rem*100 + int(c-'A'+10)) % 97
+		default:
+			return ErrIBAN
+		}
+	}
+	if rem != 1 {
+		return ErrIBAN
+	}
+	return nil
+}
+
+// IsBIC checks if a string is a valid Business Identifier Code (ISO 9362),
+// also known as SWIFT code, e.g. "DEUTDEFF" or "DEUTDEFF500".
+func IsBIC(str string) bool { return bicRxc.MatchString(str) }
+
+// BIC validates if a string is a valid Business Identifier Code.
+var BIC = verax.String(IsBIC).Error(ErrBIC)
+
+// luhn checks if the string of digits passes the Luhn checksum.
+func luhn(num string) bool {
+	var sum int
+	double := false
+	for i := len(num) - 1; i >= 0; i-- {
+		d := int(num[i] - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// isDigit checks if the character is an ASCII digit.
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// isDigits checks if the string contains only ASCII digits.
+func isDigits(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if !isDigit(str[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,380 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsCreditCard_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		number string
+		want   bool
+	}{
+		{"empty", "", false},
+		{"visa", "4111111111111111", true},
+		{"visa 13", "4222222222222", true},
+		{"mastercard", "5555555555554444", true},
+		{"mastercard 2-series", "2223003122003222", true},
+		{"amex", "378282246310005", true},
+		{"discover", "6011111111111117", true},
+		{"diners", "30569309025904", true},
+		{"jcb", "3530111333300000", true},
+		{"unionpay", "6200000000000005", true},
+		{"invalid checksum", "4111111111111112", false},
+		{"too short", "41111111116", false},
+		{"too long", "41111111111111111113", false},
+		{"not digits", "4111a11111111111", false},
+		{"with spaces", "4111 1111 1111 1111", false},
+		{"with dashes", "4111-1111-1111-1111", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsCreditCard(tc.number)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_CardBrandOf_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		number string
+		want   CardBrand
+	}{
+		{"visa", "4111111111111111", CardVisa},
+		{"mastercard", "5555555555554444", CardMastercard},
+		{"mastercard 2-series", "2223003122003222", CardMastercard},
+		{"amex 34", "341111111111111", CardAmex},
+		{"amex 37", "378282246310005", CardAmex},
+		{"discover 6011", "6011111111111117", CardDiscover},
+		{"discover 65", "6511111111111111", CardDiscover},
+		{"discover 644", "6441111111111111", CardDiscover},
+		{"diners 300", "30569309025904", CardDiners},
+		{"diners 36", "36227206271667", CardDiners},
+		{"jcb", "3530111333300000", CardJCB},
+		{"unionpay", "6200000000000005", CardUnionPay},
+		{"maestro", "6759649826438453", CardMaestro},
+		{"maestro 12 digits", "501800000009", CardMaestro},
+		{"amex invalid length", "3782822463100051", CardUnknown},
+		{"unknown", "9111111111111111", CardUnknown},
+		{"too short", "4111", CardUnknown},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := CardBrandOf(tc.number)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_CreditCard(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("4111111111111111", CreditCard)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", CreditCard)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("4111111111111112", CreditCard)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid card number (ECCreditCard)", err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", CreditCard.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", CreditCard.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid card number (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("abc", CreditCard.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_CreditCardRule_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule   CreditCardRule
+		number string
+		want   error
+	}{
+		{
+			"spaces",
+			CreditCard.Separators(" -"),
+			"4111 1111 1111 1111",
+			nil,
+		},
+		{
+			"dashes",
+			CreditCard.Separators(" -"),
+			"4111-1111-1111-1111",
+			nil,
+		},
+		{
+			"not allowed separator",
+			CreditCard.Separators(" "),
+			"4111-1111-1111-1111",
+			ErrCreditCard,
+		},
+		{
+			"leading separator",
+			CreditCard.Separators(" "),
+			" 4111111111111111",
+			ErrCreditCard,
+		},
+		{
+			"trailing separator",
+			CreditCard.Separators(" "),
+			"4111111111111111 ",
+			ErrCreditCard,
+		},
+		{
+			"brand",
+			CreditCard.Brands(CardVisa, CardMastercard),
+			"5555555555554444",
+			nil,
+		},
+		{
+			"brand not allowed",
+			CreditCard.Brands(CardVisa, CardMastercard),
+			"378282246310005",
+			ErrCardBrand,
+		},
+		{
+			"brand with separators",
+			CreditCard.Separators(" ").Brands(CardAmex),
+			"3782 822463 10005",
+			nil,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.number, tc.rule)
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_IsIBAN_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		iban string
+		want bool
+	}{
+		{"empty", "", false},
+		{"DE", "DE89370400440532013000", true},
+		{"GB", "GB29NWBK60161331926819", true},
+		{"NO", "NO9386011117947", true},
+		{"PL", "PL61109010140000071219812874", true},
+		{"FR", "FR1420041010050500013M02606", true},
+		{"invalid checksum", "DE89370400440532013001", false},
+		{"invalid check digits", "DE00370400440532013000", false},
+		{"invalid length", "DE8937040044053201300", false},
+		{"unknown country", "XX89370400440532013000", false},
+		{"lower case", "de89370400440532013000", false},
+		{"not alphanumeric", "DE89370400440532013-00", false},
+		{"letters as check digits", "DEAB370400440532013000", false},
+		{"with spaces", "DE89 3704 0044 0532 0130 00", false},
+		{"too short", "DE89", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsIBAN(tc.iban)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_IBAN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("DE89370400440532013000", IBAN)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", IBAN)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success with separators", func(t *testing.T) {
+		// --- Given ---
+		rule := IBAN.Separators(" ")
+
+		// --- When ---
+		err := verax.Validate("DE89 3704 0044 0532 0130 00", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("DE89370400440532013001", IBAN)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid IBAN (ECIBAN)", err)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", IBAN.When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", IBAN.Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid IBAN (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("abc", IBAN.Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_IsBIC_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		bic  string
+		want bool
+	}{
+		{"empty", "", false},
+		{"8 characters", "DEUTDEFF", true},
+		{"11 characters", "DEUTDEFF500", true},
+		{"with digits location", "NEDSZAJJ", true},
+		{"lower case", "deutdeff", false},
+		{"9 characters", "DEUTDEFF5", false},
+		{"digit in bank code", "DEU1DEFF", false},
+		{"digit in country", "DEUTD1FF", false},
+		{"too long", "DEUTDEFF5001", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsBIC(tc.bic)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_BIC(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("DEUTDEFF", BIC)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("DEUT", BIC)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid BIC (ECBIC)", err)
+	})
+}
+
+func Test_luhn_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		num  string
+		want bool
+	}{
+		{"valid", "79927398713", true},
+		{"invalid", "79927398710", false},
+		{"zero", "0", true},
+		{"double over 9", "59", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := luhn(tc.num)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package rule
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// phoneSeparators represents characters allowed between phone number digits.
+const phoneSeparators = " -."
+
+// phoneRegion represents phone numbering metadata of a region.
+type phoneRegion struct {
+	code   string // Country calling code.
+	trunk  string // National trunk prefix, empty when not used.
+	minLen int    // Minimum national significant number length.
+	maxLen int    // Maximum national significant number length.
+}
+
+// phoneRegions represents phone numbering metadata by ISO 3166-1 alpha-2
+// region code. The lengths are of the national significant number, which is
+// the number without the country calling code and the trunk prefix.
+var phoneRegions = map[string]phoneRegion{
+	"AE": {"971", "0", 8, 9},
+	"AR": {"54", "0", 10, 10},
+	"AT": {"43", "0", 4, 13},
+	"AU": {"61", "0", 9, 9},
+	"BE": {"32", "0", 8, 9},
+	"BR": {"55", "0", 10, 11},
+	"CA": {"1", "1", 10, 10},
+	"CH": {"41", "0", 9, 9},
+	"CN": {"86", "0", 10, 11},
+	"CZ": {"420", "", 9, 9},
+	"DE": {"49", "0", 6, 13},
+	"DK": {"45", "", 8, 8},
+	"ES": {"34", "", 9, 9},
+	"FI": {"358", "0", 5, 12},
+	"FR": {"33", "0", 9, 9},
+	"GB": {"44", "0", 9, 10},
+	"GR": {"30", "", 10, 10},
+	"HK": {"852", "", 8, 8},
+	"IE": {"353", "0", 7, 9},
+	"IL": {"972", "0", 8, 9},
+	"IN": {"91", "0", 10, 10},
+	"IT": {"39", "", 6, 11},
+	"JP": {"81", "0", 9, 10},
+	"KR": {"82", "0", 8, 10},
+	"MX": {"52", "", 10, 10},
+	"NG": {"234", "0", 8, 10},
+	"NL": {"31", "0", 9, 9},
+	"NO": {"47", "", 8, 8},
+	"NZ": {"64", "0", 8, 10},
+	"PL": {"48", "", 9, 9},
+	"PT": {"351", "", 9, 9},
+	"RU": {"7", "8", 10, 10},
+	"SE": {"46", "0", 7, 10},
+	"SG": {"65", "", 8, 8},
+	"TR": {"90", "0", 10, 10},
+	"UA": {"380", "0", 9, 9},
+	"US": {"1", "1", 10, 10},
+	"ZA": {"27", "0", 9, 9},
+}
+
+// Regexp rules.
+const (
+	// e164Rx represents valid E.164 phone number regular expression.
+	e164Rx = `^\+[1-9]\d{1,14}$`
+)
+
+// Compiled regexp rules.
+var (
+	// e164Rxc represents compiled valid E.164 phone number regular expression.
+	e164Rxc = regexp.MustCompile(e164Rx)
+)
+
+// Validation errors.
+var (
+	// ErrE164 is the error that returns in case of an invalid E.164 phone
+	// number.
+	ErrE164 = xrr.New("must be a valid E.164 phone number", "ECE164")
+
+	// ErrPhone is the error that returns in case of a malformed phone number.
+	ErrPhone = xrr.New("must be a valid phone number", "ECPhone")
+
+	// ErrPhoneCountry is the error that returns in case of a phone number with
+	// an unknown or not allowed country calling code.
+	ErrPhoneCountry = xrr.New(
+		"must be a phone number with a valid country code",
+		"ECPhoneCountry",
+	)
+
+	// ErrPhoneLength is the error that returns in case of a phone number of
+	// invalid length for its region.
+	ErrPhoneLength = xrr.New(
+		"must be a phone number of valid length",
+		"ECPhoneLength",
+	)
+)
+
+// IsE164 checks if a string is a phone number in the E.164 international
+// format, e.g. "+14155552671". The format is checked only, the country
+// calling code is not validated.
+func IsE164(str string) bool { return e164Rxc.MatchString(str) }
+
+// E164 validates if a string is a phone number in the E.164 format.
+var E164 = verax.String(IsE164).Error(ErrE164)
+
+// Phone returns a rule that checks a string is a valid phone number of the
+// given region (ISO 3166-1 alpha-2 code, e.g. "US" or "PL"). The number may
+// be in the national format with an optional trunk prefix, e.g.
+// "(201) 555-0123", or in the international format starting with "+" and the
+// region's country calling code, e.g. "+1 201 555 0123". Digits may be
+// separated with spaces, hyphens, and dots. One pair of parentheses may
+// enclose the area code, which is the first group of digits of the national
+// format, or the first group of digits after the country calling code of the
+// international format. The parentheses after the country calling code may
+// also enclose the trunk prefix, e.g. "+44 (0) 20 7946 0958", in which case
+// the prefix is not a part of the number.
+//
+// When the region is empty, only the international format is accepted, and
+// the country calling code may be of any supported region. The country
+// calling codes and number lengths are checked against an embedded metadata
+// table covering the common regions, it is not as exhaustive as the
+// libphonenumber library. The rule rejects all numbers when the region is not
+// supported.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrPhone] - the number is malformed.
+//   - [ErrPhoneCountry] - the country calling code or region is not valid.
+//   - [ErrPhoneLength] - the number length is not valid for the region.
+func Phone(region string) PhoneRule {
+	return PhoneRule{region: strings.ToUpper(region), condition: true}
+}
+
+// IsPhone checks if a string is a valid phone number of the given region
+// using the [Phone] rule.
+func IsPhone(str, region string) bool { return Phone(region).check(str) == nil }
+
+// Compile time checks.
+var (
+	_ verax.Customizer[PhoneRule]  = PhoneRule{}
+	_ verax.Conditioner[PhoneRule] = PhoneRule{}
+)
+
+// PhoneRule is a rule that checks a string is a valid phone number.
+type PhoneRule struct {
+	region    string // Region code, any region when empty.
+	condition bool   // Run validation only when true.
+	code      string // Custom error code.
+	err       error  // Custom error.
+}
+
+// Validate checks if the given value is valid or not.
+func (r PhoneRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	return validateString(v, ruleCheck(r.check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r PhoneRule) When(condition bool) PhoneRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r PhoneRule) Code(code string) PhoneRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r PhoneRule) Error(err error) PhoneRule {
+	r.err = err
+	return r
+}
+
+// check returns an error describing why the string is not a valid phone
+// number or nil if it is valid.
+func (r PhoneRule) check(str string) error {
+	if str == "" || !isDigit(str[len(str)-1]) {
+		return ErrPhone
+	}
+	if c := str[0]; c != '+' && c != '(' && !isDigit(c) {
+		return ErrPhone
+	}
+	intl := str[0] == '+'
+	head, group, tail, ok := cutPhoneGroup(strings.TrimPrefix(str, "+"))
+	if !ok {
+		return ErrPhone
+	}
+	head = removeChars(head, phoneSeparators)
+	tail = removeChars(tail, phoneSeparators)
+	if !isDigits(head) || !isDigits(tail) || head+group+tail == "" {
+		return ErrPhone
+	}
+	// The area code in parentheses must be the first group of digits of
+	// the national format or directly follow the country calling code.
+	if group != "" && intl == (head == "") {
+		return ErrPhone
+	}
+
+	if r.region == "" {
+		if !intl {
+			return ErrPhone
+		}
+		return checkIntlPhone(head, group, tail)
+	}
+	meta, ok := phoneRegions[r.region]
+	if !ok {
+		return ErrPhoneCountry
+	}
+	if intl {
+		return meta.checkIntl(head, group, tail)
+	}
+	num := head + group + tail
+	if nsn, ok := strings.CutPrefix(num, meta.trunk); ok && meta.trunk != "" {
+		// Numbers starting with the trunk prefix digits may also be
+		// national significant numbers without the prefix.
+		if meta.checkLength(nsn) == nil {
+			return nil
+		}
+	}
+	return meta.checkLength(num)
+}
+
+// checkLength checks the national significant number length.
+func (meta phoneRegion) checkLength(nsn string) error {
+	if len(nsn) < meta.minLen || len(nsn) > meta.maxLen {
+		return ErrPhoneLength
+	}
+	return nil
+}
+
+// checkIntl checks the international phone number (without "+") of the
+// region. The head is the number part before the parentheses enclosed group
+// of digits, and the tail is the part after it. When there is no group, the
+// head holds the whole number.
+func (meta phoneRegion) checkIntl(head, group, tail string) error {
+	if group == "" {
+		nsn, ok := strings.CutPrefix(head, meta.code)
+		if !ok {
+			return ErrPhoneCountry
+		}
+		return meta.checkLength(nsn)
+	}
+	if head != meta.code {
+		return ErrPhoneCountry
+	}
+	if group == meta.trunk {
+		return meta.checkLength(tail)
+	}
+	return meta.checkLength(group + tail)
+}
+
+// checkIntlPhone checks the international phone number (without "+") of any
+// supported region. See [phoneRegion.checkIntl] for the arguments.
+func checkIntlPhone(head, group, tail string) error {
+	err := ErrPhoneCountry
+	for _, meta := range phoneRegions {
+		e := meta.checkIntl(head, group, tail)
+		if e == nil {
+			return nil
+		}
+		if !errors.Is(e, ErrPhoneCountry) {
+			err = e
+		}
+	}
+	return err
+}
+
+// cutPhoneGroup cuts the phone number around the only parentheses enclosed
+// group of digits. It returns the whole number as the head when there are no
+// parentheses. Returns false when the parentheses are not balanced, there
+// is more than one pair of them, or the group is not all digits.
+func cutPhoneGroup(str string) (head, group, tail string, ok bool) {
+	opening := strings.Count(str, "(")
+	closing := strings.Count(str, ")")
+	if opening == 0 && closing == 0 {
+		return str, "", "", true
+	}
+	if opening != 1 || closing != 1 {
+		return "", "", "", false
+	}
+	head, rest, _ := strings.Cut(str, "(")
+	group, tail, ok = strings.Cut(rest, ")")
+	if !ok || group == "" || !isDigits(group) {
+		return "", "", "", false
+	}
+	return head, group, tail, true
+}
@@ -0,0 +1,218 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsE164_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		phone string
+		want  bool
+	}{
+		{"empty", "", false},
+		{"US", "+14155552671", true},
+		{"PL", "+48601234567", true},
+		{"15 digits", "+123456789012345", true},
+		{"16 digits", "+1234567890123456", false},
+		{"only plus", "+", false},
+		{"one digit", "+1", false},
+		{"leading zero", "+0123456789", false},
+		{"no plus", "14155552671", false},
+		{"spaces", "+1 415 555 2671", false},
+		{"hyphens", "+1-415-555-2671", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsE164(tc.phone)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_E164(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("+14155552671", E164)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", E164)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("+1 415 555 2671", E164)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid E.164 phone number (ECE164)", err)
+	})
+}
+
+func Test_Phone(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("(201) 555-0123", Phone("US"))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", Phone("US"))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("region is case-insensitive", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("601 234 567", Phone("pl"))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("201 555 012", Phone("US"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be a phone number of valid length (ECPhoneLength)",
+			err,
+		)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", Phone("US").When(false))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("abc", Phone("US").Code("ECMy"))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid phone number (ECMy)", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+
+		// --- When ---
+		err := verax.Validate("abc", Phone("US").Error(my))
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_PhoneRule_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		region string
+		phone  string
+		want   error
+	}{
+		{"US national", "US", "2015550123", nil},
+		{"US formatted", "US", "(201) 555-0123", nil},
+		{"US dots", "US", "201.555.0123", nil},
+		{"US trunk prefix", "US", "1 201 555 0123", nil},
+		{"US international", "US", "+1 201 555 0123", nil},
+		{"US too short", "US", "201 555 012", ErrPhoneLength},
+		{"US too long", "US", "201 555 01234", ErrPhoneLength},
+		{"US other country", "US", "+48 601 234 567", ErrPhoneCountry},
+		{"GB trunk prefix", "GB", "020 7946 0958", nil},
+		{"GB international", "GB", "+44 20 7946 0958", nil},
+		{"DE short", "DE", "030 123456", nil},
+		{"PL national", "PL", "601-234-567", nil},
+		{"PL too long", "PL", "601 234 5678", ErrPhoneLength},
+		{"RU trunk prefix", "RU", "8 812 123 45 67", nil},
+		{"RU without trunk prefix", "RU", "812 123 45 67", nil},
+		{"unknown region", "XX", "+1 201 555 0123", ErrPhoneCountry},
+		{"letters", "US", "201 555 CALL", ErrPhone},
+		{"leading hyphen", "US", "-201 555 0123", ErrPhone},
+		{"trailing separator", "US", "201 555 0123 ", ErrPhone},
+		{"plus inside", "US", "1+201 555 0123", ErrPhone},
+		{"only separators", "US", "+()", ErrPhone},
+		{"US unbalanced parentheses", "US", "((((201) 555-0123", ErrPhone},
+		{"US missing opening", "US", "201) 555-0123", ErrPhone},
+		{"US missing closing", "US", "(201 555-0123", ErrPhone},
+		{"US two groups", "US", "(201) (555) 0123", ErrPhone},
+		{"US group not area code", "US", "201 (555) 0123", ErrPhone},
+		{"US empty group", "US", "() 201 555 0123", ErrPhone},
+		{"US international area code", "US", "+1 (201) 555-0123", nil},
+		{"US group before code", "US", "+(1) 201 555 0123", ErrPhone},
+		{"GB national trunk prefix", "GB", "(020) 7946 0958", nil},
+		{"GB international trunk prefix", "GB", "+44 (0) 20 7946 0958", nil},
+		{"GB international too long", "GB", "+44 (0) 20 7946 09581", ErrPhoneLength},
+		{"GB other country trunk", "GB", "+48 (0) 601 234 567", ErrPhoneCountry},
+		{"any region US", "", "+1 201 555 0123", nil},
+		{"any region PL", "", "+48 601 234 567", nil},
+		{"any region CZ", "", "+420 601 234 567", nil},
+		{"any region national", "", "601 234 567", ErrPhone},
+		{"any region unknown code", "", "+999 123 456 789", ErrPhoneCountry},
+		{"any region bad length", "", "+48 601 234 56", ErrPhoneLength},
+		{"any region trunk prefix", "", "+44 (0) 20 7946 0958", nil},
+		{"any region area code", "", "+1 (201) 555-0123", nil},
+		{"any region bad group", "", "+1 201 (555) 0123", ErrPhoneCountry},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := verax.Validate(tc.phone, Phone(tc.region))
+
+			// --- Then ---
+			if tc.want == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, tc.want, err)
+			}
+		})
+	}
+}
+
+func Test_IsPhone(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		// --- When ---
+		have := IsPhone("+48 601 234 567", "PL")
+
+		// --- Then ---
+		assert.True(t, have)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// --- When ---
+		have := IsPhone("+48 601 234 567", "US")
+
+		// --- Then ---
+		assert.False(t, have)
+	})
+}
@@ -0,0 +1,133 @@
+package rule
+
+import (
+	"github.com/ctx42/xrr/pkg/xrr"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+// isbnSeparators represents characters allowed between ISBN digit groups.
+const isbnSeparators = " -"
+
+// Validation errors.
+var (
+	// ErrISBN is the error that returns in case of an invalid ISBN.
+	ErrISBN = xrr.New("must be a valid ISBN", "ECISBN")
+
+	// ErrISBN10 is the error that returns in case of an invalid ISBN-10.
+	ErrISBN10 = xrr.New("must be a valid ISBN-10", "ECISBN10")
+
+	// ErrISBN13 is the error that returns in case of an invalid ISBN-13.
+	ErrISBN13 = xrr.New("must be a valid ISBN-13", "ECISBN13")
+
+	// ErrGTIN is the error that returns in case of an invalid GTIN.
+	ErrGTIN = xrr.New("must be a valid GTIN", "ECGTIN")
+
+	// ErrISSN is the error that returns in case of an invalid ISSN.
+	ErrISSN = xrr.New("must be a valid ISSN", "ECISSN")
+)
+
+// IsISBN checks if a string is a valid ISBN-10 or ISBN-13.
+func IsISBN(str string) bool { return IsISBN10(str) || IsISBN13(str) }
+
+// ISBN validates if a string is a valid ISBN-10 or ISBN-13.
+var ISBN = verax.String(IsISBN).Error(ErrISBN)
+
+// IsISBN10 checks if a string is a valid ISBN-10, e.g. "0-306-40615-2". The
+// digit groups may be separated with hyphens or spaces, and the check digit
+// may be "X" representing 10.
+func IsISBN10(str string) bool {
+	isbn, ok := trimISBN(str)
+	if !ok || len(isbn) != 10 || !isDigits(isbn[:9]) {
+		return false
+	}
+	var sum int
+	for i := 0; i < 9; i++ {
+		sum += (10 - i) * int(isbn[i]-'0')
+	}
+	return checkDigit(isbn[9], (11-sum%11)%11)
+}
+
+// ISBN10 validates if a string is a valid ISBN-10.
+var ISBN10 = verax.String(IsISBN10).Error(ErrISBN10)
+
+// IsISBN13 checks if a string is a valid ISBN-13, e.g. "978-0-306-40615-7".
+// The digit groups may be separated with hyphens or spaces. The number must
+// start with the "978" or "979" prefix.
+func IsISBN13(str string) bool {
+	isbn, ok := trimISBN(str)
+	if !ok || len(isbn) != 13 || (isbn[:3] != "978" && isbn[:3] != "979") {
+		return false
+	}
+	return IsGTIN(isbn)
+}
+
+// ISBN13 validates if a string is a valid ISBN-13.
+var ISBN13 = verax.String(IsISBN13).Error(ErrISBN13)
+
+// IsGTIN checks if a string is a valid Global Trade Item Number: GTIN-8
+// (EAN-8), GTIN-12 (UPC-A), GTIN-13 (EAN-13) or GTIN-14. Separators are not
+// allowed.
+func IsGTIN(str string) bool {
+	switch len(str) {
+	case 8, 12, 13, 14:
+	default:
+		return false
+	}
+	if !isDigits(str) {
+		return false
+	}
+	var sum int
+	for i := len(str) - 2; i >= 0; i-- {
+		weight := 1
+		if (len(str)-i)%2 == 0 {
+			weight = 3
+		}
+		sum += weight * int(str[i]-'0')
+	}
+	return checkDigit(str[len(str)-1], (10-sum%10)%10)
+}
+
+// GTIN validates if a string is a valid Global Trade Item Number.
+var GTIN = verax.String(IsGTIN).Error(ErrGTIN)
+
+// IsISSN checks if a string is a valid ISSN, e.g. "0378-5955". The hyphen
+// between the digit groups is optional, and the check digit may be "X"
+// representing 10.
+func IsISSN(str string) bool {
+	if len(str) == 9 && str[4] == '-' {
+		str = str[:4] + str[5:]
+	}
+	if len(str) != 8 || !isDigits(str[:7]) {
+		return false
+	}
+	var sum int
+	for i := 0; i < 7; i++ {
+		sum += (8 - i) * int(str[i]-'0')
+	}
+	return checkDigit(str[7], (11-sum%11)%11)
+}
+
+// ISSN validates if a string is a valid ISSN.
+var ISSN = verax.String(IsISSN).Error(ErrISSN)
+
+// trimISBN removes separators from the ISBN. It returns false if the ISBN
+// starts or ends with a separator.
+func trimISBN(str string) (string, bool) {
+	if str == "" || !isAlnum(str[0]) || !isAlnum(str[len(str)-1]) {
+		return "", false
+	}
+	return removeChars(str, isbnSeparators), true
+}
+
+// checkDigit checks if the check digit character represents the given value.
+// The value 10 is represented by "X".
+func checkDigit(c byte, want int) bool {
+	if want == 10 {
+		return c == 'X'
+	}
+	return isDigit(c) && int(c-'0') == want
+}
+
+// isAlnum checks if the character is an ASCII digit or upper case letter.
+func isAlnum(c byte) bool { return isDigit(c) || (c >= 'A' && c <= 'Z') }
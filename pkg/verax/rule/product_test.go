@@ -0,0 +1,261 @@
+package rule
+
+import (
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+
+	"github.com/ctx42/verax/pkg/verax"
+)
+
+func Test_IsISBN_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		isbn string
+		want bool
+	}{
+		{"empty", "", false},
+		{"ISBN-10", "0306406152", true},
+		{"ISBN-13", "9780306406157", true},
+		{"ISBN-10 invalid", "0306406153", false},
+		{"ISBN-13 invalid", "9780306406158", false},
+		{"11 digits", "03064061521", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsISBN(tc.isbn)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ISBN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("978-0-306-40615-7", ISBN)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("", ISBN)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("0306406153", ISBN)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid ISBN (ECISBN)", err)
+	})
+}
+
+func Test_IsISBN10_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		isbn string
+		want bool
+	}{
+		{"empty", "", false},
+		{"valid", "0306406152", true},
+		{"check digit X", "080442957X", true},
+		{"hyphens", "0-306-40615-2", true},
+		{"spaces", "0 306 40615 2", true},
+		{"lower case x", "080442957x", false},
+		{"X not at the end", "X804429579", false},
+		{"invalid check digit", "0306406153", false},
+		{"leading hyphen", "-0306406152", false},
+		{"trailing hyphen", "0306406152-", false},
+		{"too short", "030640615", false},
+		{"too long", "03064061520", false},
+		{"letters", "03064A6152", false},
+		{"ISBN-13", "9780306406157", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsISBN10(tc.isbn)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ISBN10(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("0-306-40615-2", ISBN10)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("9780306406157", ISBN10)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid ISBN-10 (ECISBN10)", err)
+	})
+}
+
+func Test_IsISBN13_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		isbn string
+		want bool
+	}{
+		{"empty", "", false},
+		{"valid", "9780306406157", true},
+		{"979 prefix", "9791032305690", true},
+		{"hyphens", "978-3-16-148410-0", true},
+		{"spaces", "978 3 16 148410 0", true},
+		{"invalid check digit", "9780306406158", false},
+		{"invalid prefix", "9770306406159", false},
+		{"leading space", " 9780306406157", false},
+		{"check digit X", "978030640615X", false},
+		{"too short", "978030640615", false},
+		{"ISBN-10", "0306406152", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsISBN13(tc.isbn)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ISBN13(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("978-0-306-40615-7", ISBN13)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("0306406152", ISBN13)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid ISBN-13 (ECISBN13)", err)
+	})
+}
+
+func Test_IsGTIN_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		gtin string
+		want bool
+	}{
+		{"empty", "", false},
+		{"GTIN-8", "96385074", true},
+		{"GTIN-12", "036000291452", true},
+		{"GTIN-13", "4006381333931", true},
+		{"GTIN-14", "10012345678902", true},
+		{"GTIN-8 invalid", "96385075", false},
+		{"GTIN-12 invalid", "036000291453", false},
+		{"GTIN-13 invalid", "4006381333932", false},
+		{"GTIN-14 invalid", "10012345678903", false},
+		{"invalid length", "400638133393", false},
+		{"letters", "400638133393A", false},
+		{"hyphens", "400-6381-33393-1", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsGTIN(tc.gtin)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_GTIN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("4006381333931", GTIN)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("4006381333932", GTIN)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid GTIN (ECGTIN)", err)
+	})
+}
+
+func Test_IsISSN_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		issn string
+		want bool
+	}{
+		{"empty", "", false},
+		{"valid", "0378-5955", true},
+		{"without hyphen", "03785955", true},
+		{"check digit X", "2434-561X", true},
+		{"invalid check digit", "0378-5956", false},
+		{"misplaced hyphen", "037-85955", false},
+		{"space", "0378 5955", false},
+		{"lower case x", "2434-561x", false},
+		{"too short", "0378-595", false},
+		{"too long", "0378-59555", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := IsISSN(tc.issn)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ISSN(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("0378-5955", ISSN)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("0378-5956", ISSN)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid ISSN (ECISSN)", err)
+	})
+}
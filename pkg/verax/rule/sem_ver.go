@@ -1,7 +1,10 @@
 package rule
 
 import (
+	"cmp"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/ctx42/xrr/pkg/xrr"
 
@@ -18,8 +21,32 @@ const semVerRx string = `` +
 // semVerRxc represents semantic version compiled regular expression.
 var semVerRxc = regexp.MustCompile(semVerRx)
 
-// ErrSemVer is the error that returns in case of an invalid semver.
-var ErrSemVer = xrr.New("must be a valid semantic version", "ECSemVer")
+// Validation errors.
+var (
+	// ErrSemVer is the error that returns in case of an invalid semver.
+	ErrSemVer = xrr.New("must be a valid semantic version", "ECSemVer")
+
+	// ErrSemVerConstraint is the error that returns in case of a semantic
+	// version not satisfying the version constraint.
+	ErrSemVerConstraint = xrr.New(
+		"must be a version satisfying the constraint",
+		"ECSemVerConstraint",
+	)
+
+	// ErrSemVerPre is the error that returns in case of a pre-release version
+	// when pre-releases are not allowed.
+	ErrSemVerPre = xrr.New(
+		"must not be a pre-release version",
+		"ECSemVerPre",
+	)
+
+	// ErrVersionConstraint is the error that returns in case of an invalid
+	// version constraint.
+	ErrVersionConstraint = xrr.New(
+		"invalid version constraint",
+		verax.ECInternal,
+	)
+)
 
 // IsSemver checks if string is valid semantic version.
 func IsSemver(str string) bool {
@@ -28,3 +55,456 @@ func IsSemver(str string) bool {
 
 // SemVer validates if a string is a valid semantic version.
 var SemVer = verax.String(IsSemver).Error(ErrSemVer)
+
+// Version represents a parsed semantic version (https://semver.org).
+type Version struct {
+	Major uint64   // Major version.
+	Minor uint64   // Minor version.
+	Patch uint64   // Patch version.
+	Pre   []string // Pre-release identifiers.
+	Build []string // Build metadata identifiers.
+}
+
+// ParseVersion parses a semantic version. The version may have the "v"
+// prefix. It returns [ErrSemVer] when the string is not a valid semantic
+// version.
+func ParseVersion(str string) (Version, error) {
+	if !semVerRxc.MatchString(str) {
+		return Version{}, ErrSemVer
+	}
+	str = strings.TrimPrefix(str, "v")
+
+	var ver Version
+	str, build, ok := strings.Cut(str, "+")
+	if ok {
+		ver.Build = strings.Split(build, ".")
+	}
+	str, pre, ok := strings.Cut(str, "-")
+	if ok {
+		ver.Pre = strings.Split(pre, ".")
+	}
+
+	var err error
+	parts := strings.Split(str, ".")
+	nums := []*uint64{&ver.Major, &ver.Minor, &ver.Patch}
+	for i, num := range nums {
+		if *num, err = strconv.ParseUint(parts[i], 10, 64); err != nil {
+			return Version{}, ErrSemVer
+		}
+	}
+	return ver, nil
+}
+
+// String returns the version in the canonical form (without the "v" prefix).
+func (v Version) String() string {
+	str := strconv.FormatUint(v.Major, 10) + "." +
+		strconv.FormatUint(v.Minor, 10) + "." +
+		strconv.FormatUint(v.Patch, 10)
+	if len(v.Pre) > 0 {
+		str += "-" + strings.Join(v.Pre, ".")
+	}
+	if len(v.Build) > 0 {
+		str += "+" + strings.Join(v.Build, ".")
+	}
+	return str
+}
+
+// IsZero returns true if the version is the zero value. Validation rules
+// consider the zero version to be empty.
+func (v Version) IsZero() bool {
+	return v.Major == 0 && v.Minor == 0 && v.Patch == 0 &&
+		len(v.Pre) == 0 && len(v.Build) == 0
+}
+
+// IsPre returns true if the version is a pre-release version.
+func (v Version) IsPre() bool { return len(v.Pre) > 0 }
+
+// Compare compares the version with the other version using the semantic
+// versioning precedence rules. The build metadata is ignored. The result is
+// the same as in the [cmp.Compare] function.
+func (v Version) Compare(other Version) int {
+	if c := cmp.Compare(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+
+	// A version without pre-release identifiers has higher precedence.
+	switch {
+	case len(v.Pre) == 0 && len(other.Pre) == 0:
+		return 0
+	case len(v.Pre) == 0:
+		return 1
+	case len(other.Pre) == 0:
+		return -1
+	}
+	for i := 0; i < len(v.Pre) && i < len(other.Pre); i++ {
+		if c := comparePreID(v.Pre[i], other.Pre[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(v.Pre), len(other.Pre))
+}
+
+// comparePreID compares pre-release identifiers. Numeric identifiers are
+// compared numerically and have lower precedence than alphanumeric ones.
+func comparePreID(a, b string) int {
+	aNum, bNum := isDigits(a), isDigits(b)
+	switch {
+	case aNum && bNum:
+		// Numeric identifiers have no leading zeros.
+		if c := cmp.Compare(len(a), len(b)); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// Version comparison operators.
+const (
+	verEQ = iota // Equal.
+	verNE        // Not equal.
+	verGT        // Greater than.
+	verGE        // Greater than or equal.
+	verLT        // Less than.
+	verLE        // Less than or equal.
+)
+
+// verComparator represents a single version comparison.
+type verComparator struct {
+	op  int     // Comparison operator.
+	ver Version // Version to compare with.
+}
+
+// check checks the version satisfies the comparison.
+func (c verComparator) check(ver Version) bool {
+	res := ver.Compare(c.ver)
+	switch c.op {
+	case verEQ:
+		return res == 0
+	case verNE:
+		return res != 0
+	case verGT:
+		return res > 0
+	case verGE:
+		return res >= 0
+	case verLT:
+		return res < 0
+	case verLE:
+		return res <= 0
+	}
+	return false
+}
+
+// VersionConstraint represents a parsed semantic version constraint.
+type VersionConstraint struct {
+	str    string            // Constraint as given.
+	groups [][]verComparator // Alternatives of comparator sets.
+}
+
+// ParseVersionConstraint parses a semantic version constraint. The
+// constraint is a list of alternatives separated by "||". Each alternative
+// is a list of comparators separated by spaces or commas, all of which must be
+// satisfied. The supported comparators are:
+//
+//   - "1.2.3", "=1.2.3" - equal to the version.
+//   - "!=1.2.3" - not equal to the version.
+//   - ">1.2.3", ">=1.2.3", "<1.2.3", "<=1.2.3" - version comparisons.
+//   - "~1.2.3" - patch updates: ">=1.2.3 <1.3.0".
+//   - "^1.2.3" - updates not changing the leftmost non-zero number:
+//     ">=1.2.3 <2.0.0", "^0.2.3" is ">=0.2.3 <0.3.0".
+//   - "*", "x" - any version.
+//
+// Versions may be partial, e.g. "1.2" or "1.x", missing numbers are
+// wildcards, e.g. "1.2" is ">=1.2.0 <1.3.0" and "^3.1" is ">=3.1.0 <4.0.0".
+// Synthesized upper bounds exclude pre-releases of the bound, e.g. "^1.2.3"
+// does not match "2.0.0-rc.1". The same applies to explicit "<" bounds without
+// pre-release identifiers, e.g. "<2.0.0" does not match "2.0.0-rc.1", while
+// "<2.0.0-rc.2" does.
+//
+// It returns an error wrapping [ErrVersionConstraint] when the constraint is
+// not valid.
+func ParseVersionConstraint(str string) (VersionConstraint, error) {
+	vc := VersionConstraint{str: str}
+	for _, alt := range strings.Split(str, "||") {
+		var group []verComparator
+		fields := strings.Fields(strings.ReplaceAll(alt, ",", " "))
+		if len(fields) == 0 {
+			return VersionConstraint{}, constraintError(str)
+		}
+		for i := 0; i < len(fields); i++ {
+			field := fields[i]
+			// Operator separated from the version with a space.
+			if strings.Trim(field, "=!<>~^") == "" && i+1 < len(fields) {
+				i++
+				field += fields[i]
+			}
+			cs, ok := parseComparator(field)
+			if !ok {
+				return VersionConstraint{}, constraintError(str)
+			}
+			group = append(group, cs...)
+		}
+		vc.groups = append(vc.groups, group)
+	}
+	return vc, nil
+}
+
+// String returns the constraint as given to [ParseVersionConstraint].
+func (vc VersionConstraint) String() string { return vc.str }
+
+// Check checks if the version satisfies the constraint.
+func (vc VersionConstraint) Check(ver Version) bool {
+	for _, group := range vc.groups {
+		ok := true
+		for _, c := range group {
+			if !c.check(ver) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// constraintError returns an error for the invalid constraint.
+func constraintError(str string) error {
+	meta := xrr.Meta().Str("constraint", str).Option()
+	return xrr.Wrap(ErrVersionConstraint, meta)
+}
+
+// parseComparator parses a single comparator and converts it to one or more
+// basic comparisons.
+func parseComparator(str string) ([]verComparator, bool) {
+	var op string
+	for _, prefix := range []string{">=", "<=", "!=", ">", "<", "=", "~", "^"} {
+		if rest, ok := strings.CutPrefix(str, prefix); ok {
+			op, str = prefix, rest
+			break
+		}
+	}
+	ver, n, ok := parsePartialVersion(str)
+	if !ok {
+		return nil, false
+	}
+
+	if n == 0 {
+		switch op {
+		case "", "=", ">=", "<=", "~", "^":
+			return nil, true // Any version.
+		default:
+			return nil, false
+		}
+	}
+
+	switch op {
+	case "", "=":
+		if n == 3 {
+			return []verComparator{{verEQ, ver}}, true
+		}
+		return []verComparator{{verGE, ver}, {verLT, bumpVersion(ver, n)}}, true
+	case "!=":
+		if n == 3 {
+			return []verComparator{{verNE, ver}}, true
+		}
+		return nil, false
+	case ">":
+		if n == 3 {
+			return []verComparator{{verGT, ver}}, true
+		}
+		return []verComparator{{verGE, bumpVersion(ver, n)}}, true
+	case ">=":
+		return []verComparator{{verGE, ver}}, true
+	case "<":
+		if len(ver.Pre) == 0 {
+			ver.Pre = []string{"0"} // Exclude pre-releases of the bound.
+		}
+		return []verComparator{{verLT, ver}}, true
+	case "<=":
+		if n == 3 {
+			return []verComparator{{verLE, ver}}, true
+		}
+		return []verComparator{{verLT, bumpVersion(ver, n)}}, true
+	case "~":
+		up := bumpVersion(ver, min(n, 2))
+		return []verComparator{{verGE, ver}, {verLT, up}}, true
+	}
+
+	// The "^" operator.
+	level := 3
+	switch {
+	case ver.Major > 0 || n == 1:
+		level = 1
+	case ver.Minor > 0 || n == 2:
+		level = 2
+	}
+	up := bumpVersion(ver, level)
+	return []verComparator{{verGE, ver}, {verLT, up}}, true
+}
+
+// parsePartialVersion parses a version which may be partial, e.g. "1.2",
+// "1.x" or "*". It returns the version with missing numbers set to zero and
+// the number of specified numbers. Pre-release and build metadata are
+// allowed only in full versions.
+func parsePartialVersion(str string) (Version, int, bool) {
+	if ver, err := ParseVersion(str); err == nil {
+		return ver, 3, true
+	}
+	str = strings.TrimPrefix(str, "v")
+	parts := strings.Split(str, ".")
+	if str == "" || len(parts) > 3 {
+		return Version{}, 0, false
+	}
+
+	var ver Version
+	nums := []*uint64{&ver.Major, &ver.Minor, &ver.Patch}
+	n := 0
+	for i, part := range parts {
+		if part == "x" || part == "X" || part == "*" {
+			continue
+		}
+		if n != i || (len(part) > 1 && part[0] == '0') {
+			return Version{}, 0, false // Number after a wildcard.
+		}
+		num, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return Version{}, 0, false
+		}
+		*nums[i] = num
+		n++
+	}
+	return ver, n, true
+}
+
+// bumpVersion returns the lowest pre-release of the version with the number
+// at the given level (1 - major, 2 - minor, 3 - patch) incremented.
+func bumpVersion(ver Version, level int) Version {
+	switch level {
+	case 1:
+		ver = Version{Major: ver.Major + 1}
+	case 2:
+		ver = Version{Major: ver.Major, Minor: ver.Minor + 1}
+	default:
+		ver = Version{Major: ver.Major, Minor: ver.Minor, Patch: ver.Patch + 1}
+	}
+	ver.Pre = []string{"0"}
+	return ver
+}
+
+// SemVerConstraint returns a rule that checks a string or a [Version] is a
+// valid semantic version satisfying the constraint. See
+// [ParseVersionConstraint] for the constraint syntax. The rule returns
+// [verax.ErrInvSetup] for all the values when the constraint is not valid.
+//
+// Each failure reason is reported with a distinct error:
+//
+//   - [ErrSemVer] - the string is not a valid semantic version.
+//   - [ErrSemVerPre] - the version is a not allowed pre-release.
+//   - [ErrSemVerConstraint] - the version does not satisfy the constraint.
+//
+// Example:
+//
+//	rule := SemVerConstraint(">=1.2.0 <2.0.0 || ^3.1")
+func SemVerConstraint(constraint string) SemVerRule {
+	vc, err := ParseVersionConstraint(constraint)
+	return SemVerRule{constraint: vc, invalid: err != nil, condition: true}
+}
+
+// SemVerMin returns a rule that checks a string or a [Version] is a valid
+// semantic version no lower than the given version. It is the same as
+// SemVerConstraint(">=" + ver).
+func SemVerMin(ver string) SemVerRule { return SemVerConstraint(">=" + ver) }
+
+// Compile time checks.
+var (
+	_ verax.Customizer[SemVerRule]  = SemVerRule{}
+	_ verax.Conditioner[SemVerRule] = SemVerRule{}
+)
+
+// SemVerRule is a rule that checks a semantic version satisfies a constraint.
+type SemVerRule struct {
+	constraint VersionConstraint // Version constraint.
+	invalid    bool              // The constraint is not valid.
+	noPre      bool              // Reject pre-release versions.
+	condition  bool              // Run validation only when true.
+	code       string            // Custom error code.
+	err        error             // Custom error.
+}
+
+// NoPrerelease configures the rule to reject pre-release versions, even if
+// they satisfy the constraint.
+func (r SemVerRule) NoPrerelease() SemVerRule {
+	r.noPre = true
+	return r
+}
+
+// Validate checks if the given value is valid or not.
+func (r SemVerRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+	if r.invalid {
+		return verax.ErrInvSetup
+	}
+	if isNil, _ := verax.IsNil(v); isNil {
+		return nil
+	}
+	if ver, ok := verax.Indirect(v).(Version); ok {
+		if verax.IsEmpty(ver) {
+			return nil
+		}
+		return ruleError(r.check(ver), r.err, r.code)
+	}
+	check := func(str string) error {
+		ver, err := ParseVersion(str)
+		if err != nil {
+			return err
+		}
+		return r.check(ver)
+	}
+	return validateString(v, ruleCheck(check, r.err, r.code))
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r SemVerRule) When(condition bool) SemVerRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r SemVerRule) Code(code string) SemVerRule {
+	r.code = code
+	return r
+}
+
+// Error sets custom error for the rule. The error is returned for all the
+// failure reasons.
+func (r SemVerRule) Error(err error) SemVerRule {
+	r.err = err
+	return r
+}
+
+// check checks the version.
+func (r SemVerRule) check(ver Version) error {
+	if r.noPre && ver.IsPre() {
+		return ErrSemVerPre
+	}
+	if !r.constraint.Check(ver) {
+		return ErrSemVerConstraint
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package rule
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ctx42/testing/pkg/assert"
@@ -92,3 +93,474 @@ func Test_SemVer_errors_tabular(t *testing.T) {
 		})
 	}
 }
+
+func Test_ParseVersion(t *testing.T) {
+	t.Run("full", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersion("v1.2.3-rc.1+build.5")
+
+		// --- Then ---
+		assert.NoError(t, err)
+		want := Version{
+			Major: 1,
+			Minor: 2,
+			Patch: 3,
+			Pre:   []string{"rc", "1"},
+			Build: []string{"build", "5"},
+		}
+		assert.Equal(t, want, have)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersion("10.20.30")
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, Version{Major: 10, Minor: 20, Patch: 30}, have)
+	})
+
+	t.Run("build with hyphen", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersion("1.0.0+exp-sha.5")
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Nil(t, have.Pre)
+		assert.Equal(t, []string{"exp-sha", "5"}, have.Build)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersion("1.0")
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrSemVer, err)
+		assert.Zero(t, have)
+	})
+
+	t.Run("error number overflow", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersion("1.0.99999999999999999999")
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrSemVer, err)
+		assert.Zero(t, have)
+	})
+}
+
+func Test_Version_String_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		ver  string
+		want string
+	}{
+		{"release", "1.2.3", "1.2.3"},
+		{"v prefix", "v1.2.3", "1.2.3"},
+		{"pre-release", "1.2.3-rc.1", "1.2.3-rc.1"},
+		{"build", "1.2.3+build.5", "1.2.3+build.5"},
+		{"pre-release and build", "1.2.3-rc.1+b5", "1.2.3-rc.1+b5"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- Given ---
+			ver, _ := ParseVersion(tc.ver)
+
+			// --- When ---
+			have := ver.String()
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_Version_IsZero(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		// --- When ---
+		have := Version{}.IsZero()
+
+		// --- Then ---
+		assert.True(t, have)
+	})
+
+	t.Run("not zero", func(t *testing.T) {
+		// --- Given ---
+		ver := Version{Pre: []string{"alpha"}}
+
+		// --- When ---
+		have := ver.IsZero()
+
+		// --- Then ---
+		assert.False(t, have)
+	})
+}
+
+func Test_Version_IsPre(t *testing.T) {
+	t.Run("pre-release", func(t *testing.T) {
+		// --- Given ---
+		ver, _ := ParseVersion("1.0.0-alpha")
+
+		// --- When ---
+		have := ver.IsPre()
+
+		// --- Then ---
+		assert.True(t, have)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		// --- Given ---
+		ver, _ := ParseVersion("1.0.0+build")
+
+		// --- When ---
+		have := ver.IsPre()
+
+		// --- Then ---
+		assert.False(t, have)
+	})
+}
+
+func Test_Version_Compare_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		a    string
+		b    string
+		want int
+	}{
+		{"equal", "1.2.3", "1.2.3", 0},
+		{"equal ignoring build", "1.2.3+a", "1.2.3+b", 0},
+		{"major", "1.9.9", "2.0.0", -1},
+		{"minor", "1.2.9", "1.10.0", -1},
+		{"patch", "1.2.10", "1.2.9", 1},
+		{"pre-release lower than release", "1.0.0-rc.1", "1.0.0", -1},
+		{"release higher than pre-release", "1.0.0", "1.0.0-rc.1", 1},
+		{"alpha < alpha.1", "1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"alpha.1 < alpha.beta", "1.0.0-alpha.1", "1.0.0-alpha.beta", -1},
+		{"alpha.beta < beta", "1.0.0-alpha.beta", "1.0.0-beta", -1},
+		{"beta < beta.2", "1.0.0-beta", "1.0.0-beta.2", -1},
+		{"beta.2 < beta.11", "1.0.0-beta.2", "1.0.0-beta.11", -1},
+		{"beta.11 < rc.1", "1.0.0-beta.11", "1.0.0-rc.1", -1},
+		{"numeric < alphanumeric", "1.0.0-1", "1.0.0-a", -1},
+		{"alphanumeric > numeric", "1.0.0-a", "1.0.0-1", 1},
+		{"equal pre-release", "1.0.0-rc.1", "1.0.0-rc.1", 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- Given ---
+			a, _ := ParseVersion(tc.a)
+			b, _ := ParseVersion(tc.b)
+
+			// --- When ---
+			have := a.Compare(b)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_ParseVersionConstraint(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersionConstraint(">=1.2.0 <2.0.0 || ^3.1")
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, ">=1.2.0 <2.0.0 || ^3.1", have.String())
+		assert.Len(t, 2, have.groups)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		have, err := ParseVersionConstraint(">=1.2.0 ||")
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrVersionConstraint, err)
+		xrrtest.AssertCode(t, verax.ECInternal, err)
+		assert.Zero(t, have)
+	})
+}
+
+func Test_ParseVersionConstraint_errors_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		constraint string
+	}{
+		{"empty", ""},
+		{"empty alternative", "1.2.3 ||"},
+		{"invalid version", ">=1.2.3.4"},
+		{"invalid operator", "=>1.2.3"},
+		{"leading zero", "01.2"},
+		{"number after wildcard", "1.x.3"},
+		{"partial pre-release", "1.2-rc.1"},
+		{"partial not equal", "!=1.2"},
+		{"greater than any", ">*"},
+		{"letters", "abc"},
+		{"only operator", ">="},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			_, err := ParseVersionConstraint(tc.constraint)
+
+			// --- Then ---
+			assert.ErrorIs(t, ErrVersionConstraint, err)
+		})
+	}
+}
+
+func Test_VersionConstraint_Check_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		constraint string
+		ver        string
+		want       bool
+	}{
+		{"exact", "1.2.3", "1.2.3", true},
+		{"exact not", "1.2.3", "1.2.4", false},
+		{"exact with v", "v1.2.3", "1.2.3", true},
+		{"equal", "=1.2.3", "1.2.3", true},
+		{"not equal", "!=1.2.3", "1.2.4", true},
+		{"not equal same", "!=1.2.3", "1.2.3", false},
+		{"greater", ">1.2.3", "1.2.4", true},
+		{"greater same", ">1.2.3", "1.2.3", false},
+		{"greater or equal", ">=1.2.3", "1.2.3", true},
+		{"less", "<1.2.3", "1.2.2", true},
+		{"less same", "<1.2.3", "1.2.3", false},
+		{"less pre-release of bound", "<1.2.3", "1.2.3-rc.1", false},
+		{"less pre-release of lower", "<1.2.3", "1.2.2-rc.1", true},
+		{"less pre-release bound", "<1.2.3-rc.2", "1.2.3-rc.1", true},
+		{"less pre-release bound same", "<1.2.3-rc.2", "1.2.3-rc.2", false},
+		{"less or equal", "<=1.2.3", "1.2.3", true},
+		{"space after operator", ">= 1.2.3", "1.2.3", true},
+		{"comma separated", ">=1.2.0, <2.0.0", "1.5.0", true},
+		{"range", ">=1.2.0 <2.0.0", "1.9.9", true},
+		{"range upper", ">=1.2.0 <2.0.0", "2.0.0", false},
+		{"range lower", ">=1.2.0 <2.0.0", "1.1.9", false},
+		{"or first", ">=1.2.0 <2.0.0 || ^3.1", "1.2.0", true},
+		{"or second", ">=1.2.0 <2.0.0 || ^3.1", "3.9.0", true},
+		{"or none", ">=1.2.0 <2.0.0 || ^3.1", "3.0.9", false},
+		{"or pre-release of upper", ">=1.2.0 <2.0.0 || ^3.1", "2.0.0-rc.1", false},
+		{"caret", "^1.2.3", "1.9.0", true},
+		{"caret lower", "^1.2.3", "1.2.2", false},
+		{"caret upper", "^1.2.3", "2.0.0", false},
+		{"caret upper pre-release", "^1.2.3", "2.0.0-rc.1", false},
+		{"caret pre-release", "^1.2.3-beta", "1.2.3-beta.2", true},
+		{"caret zero major", "^0.2.3", "0.2.9", true},
+		{"caret zero major upper", "^0.2.3", "0.3.0", false},
+		{"caret zero minor", "^0.0.3", "0.0.3", true},
+		{"caret zero minor upper", "^0.0.3", "0.0.4", false},
+		{"caret partial", "^3.1", "3.1.0", true},
+		{"caret partial upper", "^3.1", "4.0.0", false},
+		{"caret major only", "^0", "0.9.0", true},
+		{"caret zero partial", "^0.0", "0.1.0", false},
+		{"tilde", "~1.2.3", "1.2.9", true},
+		{"tilde upper", "~1.2.3", "1.3.0", false},
+		{"tilde major only", "~1", "1.9.0", true},
+		{"tilde major only upper", "~1", "2.0.0", false},
+		{"partial", "1.2", "1.2.9", true},
+		{"partial upper", "1.2", "1.3.0", false},
+		{"wildcard", "1.x", "1.9.9", true},
+		{"wildcard upper", "1.x", "2.0.0", false},
+		{"wildcard star", "1.2.*", "1.2.5", true},
+		{"any", "*", "0.0.1", true},
+		{"any x", "x", "9.9.9", true},
+		{"greater partial", ">1.2", "1.2.9", false},
+		{"greater partial next", ">1.2", "1.3.0", true},
+		{"greater or equal partial", ">=1.2", "1.2.0", true},
+		{"less partial", "<1.2", "1.1.9", true},
+		{"less partial pre-release", "<1.2", "1.2.0-rc.1", false},
+		{"less or equal partial", "<=1.2", "1.2.9", true},
+		{"less or equal partial upper", "<=1.2", "1.3.0", false},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- Given ---
+			vc, err := ParseVersionConstraint(tc.constraint)
+			assert.NoError(t, err)
+			ver, err := ParseVersion(tc.ver)
+			assert.NoError(t, err)
+
+			// --- When ---
+			have := vc.Check(ver)
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_SemVerConstraint(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint(">=1.2.0 <2.0.0 || ^3.1")
+
+		// --- When ---
+		err := verax.Validate("v3.2.0", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success version", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("^1.2")
+		ver := Version{Major: 1, Minor: 3}
+
+		// --- When ---
+		err := verax.Validate(ver, rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success version pointer", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("^1.2")
+		ver := &Version{Major: 1, Minor: 3}
+
+		// --- When ---
+		err := verax.Validate(ver, rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("success when empty", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("^1.2")
+
+		// --- When ---
+		errStr := verax.Validate("", rule)
+		errVer := verax.Validate(Version{}, rule)
+		errPtr := verax.Validate((*Version)(nil), rule)
+
+		// --- Then ---
+		assert.NoError(t, errStr)
+		assert.NoError(t, errVer)
+		assert.NoError(t, errPtr)
+	})
+
+	t.Run("error not satisfied", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint(">=1.2.0 <2.0.0 || ^3.1")
+
+		// --- When ---
+		err := verax.Validate("2.0.0", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must be a version satisfying the constraint (ECSemVerConstraint)",
+			err,
+		)
+	})
+
+	t.Run("error invalid version", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("^1.2")
+
+		// --- When ---
+		err := verax.Validate("1.2", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "must be a valid semantic version (ECSemVer)", err)
+	})
+
+	t.Run("error invalid constraint", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("=>1.2")
+
+		// --- When ---
+		err := verax.Validate("1.2.0", rule)
+
+		// --- Then ---
+		assert.Same(t, verax.ErrInvSetup, err)
+	})
+
+	t.Run("pre-release allowed by default", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint(">=1.0.0")
+
+		// --- When ---
+		err := verax.Validate("1.2.0-rc.1", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("no pre-release", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint(">=1.0.0").NoPrerelease()
+
+		// --- When ---
+		err := verax.Validate("1.2.0-rc.1", rule)
+
+		// --- Then ---
+		xrrtest.AssertEqual(
+			t,
+			"must not be a pre-release version (ECSemVerPre)",
+			err,
+		)
+	})
+
+	t.Run("condition false", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("^1.2").When(false)
+
+		// --- When ---
+		err := verax.Validate("abc", rule)
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("custom code", func(t *testing.T) {
+		// --- Given ---
+		rule := SemVerConstraint("^1.2").Code("ECMy")
+
+		// --- When ---
+		err := verax.Validate("2.0.0", rule)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, "ECMy", err)
+	})
+
+	t.Run("custom error", func(t *testing.T) {
+		// --- Given ---
+		my := errors.New("my error")
+		rule := SemVerConstraint("^1.2").Error(my)
+
+		// --- When ---
+		err := verax.Validate("2.0.0", rule)
+
+		// --- Then ---
+		assert.Same(t, my, err)
+	})
+}
+
+func Test_SemVerMin(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("1.4.0", SemVerMin("1.4.0"))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		// --- When ---
+		err := verax.Validate("1.4.0-rc.1", SemVerMin("1.4.0"))
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrSemVerConstraint, err)
+	})
+}
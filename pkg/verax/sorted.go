@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+)
+
+// ECNotSorted represents error code for not sorted values.
+const ECNotSorted = "ECNotSorted"
+
+// Ordering errors.
+var (
+	// ErrNotSortedAsc is the error returned when values are not sorted in
+	// ascending order.
+	ErrNotSortedAsc = xrr.New("must be sorted in ascending order", ECNotSorted)
+
+	// ErrNotSortedDesc is the error returned when values are not sorted in
+	// descending order.
+	ErrNotSortedDesc = xrr.New("must be sorted in descending order", ECNotSorted)
+
+	// ErrNotIncreasing is the error returned when values are not strictly
+	// increasing.
+	ErrNotIncreasing = xrr.New("must be strictly increasing", ECNotSorted)
+)
+
+// Sorted creates a validation rule that checks if slice or array elements are
+// sorted in ascending order. The elements must be of the same type,
+// supporting only int, uint, float, and time.Time types. Use [SortedBy] for
+// other types. The error is reported under the index of the first element
+// breaking the order. Empty slices and arrays are considered valid.
+func Sorted() SortedRule {
+	return SortedRule{
+		operator:  greaterEqualThan,
+		condition: true,
+		err:       ErrNotSortedAsc,
+	}
+}
+
+// SortedDesc creates a validation rule that checks if slice or array elements
+// are sorted in descending order. See [Sorted] for details.
+func SortedDesc() SortedRule {
+	return SortedRule{
+		operator:  lessEqualThan,
+		condition: true,
+		err:       ErrNotSortedDesc,
+	}
+}
+
+// StrictlyIncreasing creates a validation rule that checks if slice or array
+// elements are sorted in ascending order without duplicates. See [Sorted] for
+// details.
+func StrictlyIncreasing() SortedRule {
+	return SortedRule{
+		operator:  greaterThan,
+		condition: true,
+		err:       ErrNotIncreasing,
+	}
+}
+
+// SortedBy creates a validation rule that checks if slice or array elements
+// are sorted in ascending order using the given comparison function. The
+// function is called with the previous element as "want" and the next element
+// as "have". See [Sorted] for details.
+func SortedBy(fn CompareFunc) SortedRule {
+	r := Sorted()
+	r.with = fn
+	return r
+}
+
+// Compile time checks.
+var (
+	_ Customizer[SortedRule]  = SortedRule{}
+	_ Conditioner[SortedRule] = SortedRule{}
+)
+
+// SortedRule is a rule validating slice or array elements are ordered.
+type SortedRule struct {
+	operator  int         // The comparison operator.
+	with      CompareFunc // Custom comparison function.
+	condition bool        // Run validation only when true.
+	err       error       // Validation error.
+}
+
+// Validate checks if the given value is valid or not.
+func (r SortedRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+
+	vo := reflect.ValueOf(v)
+	switch vo.Kind() { // nolint: exhaustive
+	case reflect.Slice, reflect.Array:
+	default:
+		return xrr.New("must be a slice or an array", ECInvType)
+	}
+	if vo.Len() < 2 {
+		return nil
+	}
+
+	fn := r.with
+	prev := getInterface(vo.Index(0))
+	if fn == nil {
+		if fn = compareFor(prev); fn == nil {
+			msg := fmt.Sprintf("type is not supported: %T", prev)
+			return xrr.New(msg, ECInvType)
+		}
+	}
+
+	for i := 1; i < vo.Len(); i++ {
+		next := getInterface(vo.Index(i))
+		res, err := fn(prev, next)
+		if err != nil {
+			return err
+		}
+		if !thresholdOutcome(r.operator, res) {
+			return xrr.Fields{strconv.Itoa(i): r.err}
+		}
+		prev = next
+	}
+	return nil
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r SortedRule) When(condition bool) SortedRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r SortedRule) Code(code string) SortedRule {
+	r.err = setCode(r.err, code)
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r SortedRule) Error(err error) SortedRule {
+	r.err = err
+	return r
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+)
+
+func Test_SortedRule_Validate_valid_tabular(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+
+	tt := []struct {
+		testN string
+
+		val  any
+		rule SortedRule
+	}{
+		{"nil slice", []int(nil), Sorted()},
+		{"one element", []int{1}, Sorted()},
+		{"int ascending", []int{1, 2, 2, 3}, Sorted()},
+		{"uint ascending", []uint8{1, 2, 2, 3}, Sorted()},
+		{"float ascending", []float64{-1.5, 0, 2.5}, Sorted()},
+		{"time ascending", []time.Time{t0, t0, t1}, Sorted()},
+		{"array ascending", [...]int{1, 2, 3}, Sorted()},
+		{"int descending", []int{3, 2, 2, 1}, SortedDesc()},
+		{"time descending", []time.Time{t1, t0}, SortedDesc()},
+		{"strictly increasing", []int{1, 2, 3}, StrictlyIncreasing()},
+		{"condition false", []int{2, 1}, Sorted().When(false)},
+		{
+			"sorted by",
+			[]string{"a", "B", "c"},
+			SortedBy(func(want, have any) (int, error) {
+				w := strings.ToLower(want.(string))
+				h := strings.ToLower(have.(string))
+				return cmp.Compare(w, h), nil
+			}),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := tc.rule.Validate(tc.val)
+
+			// --- Then ---
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_SortedRule_Validate_invalid_tabular(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	t1 := t0.Add(time.Second)
+
+	tt := []struct {
+		testN string
+
+		val  any
+		rule SortedRule
+		err  string
+	}{
+		{
+			"not a slice",
+			map[int]int{1: 1},
+			Sorted(),
+			"must be a slice or an array (ECInvType)",
+		},
+		{
+			"not supported type",
+			[]string{"a", "b"},
+			Sorted(),
+			"type is not supported: string (ECInvType)",
+		},
+		{
+			"mixed types",
+			[]any{1, 2.0},
+			Sorted(),
+			"cannot convert float64 to int64 (ECInvType)",
+		},
+		{
+			"int ascending",
+			[]int{1, 3, 2, 1},
+			Sorted(),
+			"2: must be sorted in ascending order (ECNotSorted)",
+		},
+		{
+			"time ascending",
+			[]time.Time{t1, t0},
+			Sorted(),
+			"1: must be sorted in ascending order (ECNotSorted)",
+		},
+		{
+			"int descending",
+			[]int{3, 2, 4},
+			SortedDesc(),
+			"2: must be sorted in descending order (ECNotSorted)",
+		},
+		{
+			"strictly increasing",
+			[]float64{1, 2, 2},
+			StrictlyIncreasing(),
+			"2: must be strictly increasing (ECNotSorted)",
+		},
+		{
+			"sorted by",
+			[]string{"b", "a"},
+			SortedBy(func(want, have any) (int, error) {
+				return cmp.Compare(want.(string), have.(string)), nil
+			}),
+			"1: must be sorted in ascending order (ECNotSorted)",
+		},
+		{
+			"custom code",
+			[]int{2, 1},
+			Sorted().Code("ECCustom"),
+			"1: must be sorted in ascending order (ECCustom)",
+		},
+		{
+			"custom error",
+			[]int{2, 1},
+			Sorted().Error(ErrTst),
+			"1: tst msg (ETstCode)",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := tc.rule.Validate(tc.val)
+
+			// --- Then ---
+			xrrtest.AssertEqual(t, tc.err, err)
+		})
+	}
+}
@@ -72,6 +72,9 @@ type FieldRules struct {
 //	fmt.Println(err)
 //	// Value: the length must be between 5 and 10.
 //
+// Values produced by [Transformer] rules are written back to the struct
+// fields.
+//
 // Returns error with ECInternal code on unexpected errors, otherwise it
 // returns xrr.Fields error.
 //
@@ -101,7 +104,11 @@ func ValidateStruct(v any, fields ...*FieldRules) error {
 		}
 
 		v = fv.Elem().Interface()
-		if err := Validate(v, fr.rules...); err != nil {
+		tv, err := validate(v, fr.rules...)
+		if hasTransformer(fr.rules) {
+			setField(fv.Elem(), tv)
+		}
+		if err != nil {
 			if xrr.GetCode(err) == ECInternal {
 				msg := fmt.Sprintf("%s: %s", getErrorFieldName(fr.tag, sf), err)
 				return xrr.New(msg, ECInternal)
@@ -139,6 +146,27 @@ func (fr *FieldRules) Tag(tag string) *FieldRules {
 	return fr
 }
 
+// hasTransformer returns true if any of the rules is a [Transformer].
+func hasTransformer(rules []Rule) bool {
+	for _, rule := range rules {
+		if _, ok := rule.(Transformer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// setField sets the struct field to the value v if the field is settable and
+// the value is assignable to it.
+func setField(field reflect.Value, v any) {
+	if v == nil || !field.CanSet() {
+		return
+	}
+	if val := reflect.ValueOf(v); val.Type().AssignableTo(field.Type()) {
+		field.Set(val)
+	}
+}
+
 // findStructField looks for a field in the given struct.
 // The field being looked for should be a pointer to the actual struct field.
 // If found, the field info will be returned. Otherwise, nil will be returned.
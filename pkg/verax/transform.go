@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Transformer is implemented by rules which normalize a value before the
+// rules following them are run. When used with [Validate], the transformed
+// value is passed to the following rules. When used with [ValidateStruct],
+// the transformed value is also written back to the struct field.
+type Transformer interface {
+	Rule
+
+	// Transform returns the transformed value or error if the value cannot be
+	// transformed.
+	Transform(v any) (any, error)
+}
+
+// TransformFunc represents a value transforming function.
+type TransformFunc func(v any) (any, error)
+
+// Transformer rules.
+var (
+	// Trim removes leading and trailing white space from a string.
+	Trim = TransformString(strings.TrimSpace)
+
+	// ToLower maps a string to its lower case.
+	ToLower = TransformString(strings.ToLower)
+
+	// ToUpper maps a string to its upper case.
+	ToUpper = TransformString(strings.ToUpper)
+
+	// CollapseSpace trims a string and replaces all sequences of white space
+	// characters inside it with a single space.
+	CollapseSpace = TransformString(collapseSpace)
+
+	// NormalizeNFC converts a string to the Unicode Normalization Form C.
+	NormalizeNFC = TransformString(norm.NFC.String)
+)
+
+// Transform returns a transformer rule using the given [TransformFunc].
+func Transform(fn TransformFunc) TransformRule {
+	return TransformRule{fn: fn, condition: true}
+}
+
+// TransformString returns a transformer rule which applies the given function
+// to strings and byte slices. Pointers to them are transformed into new
+// pointers, the values they point to are never modified. Nil values are
+// returned unchanged. For all other types, the rule returns an error.
+func TransformString(fn func(string) string) TransformRule {
+	return Transform(stringTransformer(fn))
+}
+
+// Compile time checks.
+var (
+	_ Transformer                = TransformRule{}
+	_ Conditioner[TransformRule] = TransformRule{}
+)
+
+// TransformRule is a rule which transforms a value before the following rules
+// are run.
+type TransformRule struct {
+	fn        TransformFunc // Transform function.
+	condition bool          // Transform only when true.
+}
+
+// Validate checks if the given value can be transformed.
+func (r TransformRule) Validate(v any) error {
+	_, err := r.Transform(v)
+	return err
+}
+
+// Transform returns the transformed value.
+func (r TransformRule) Transform(v any) (any, error) {
+	if !r.condition {
+		return v, nil
+	}
+	if r.fn == nil {
+		return nil, ErrInvSetup
+	}
+	return r.fn(v)
+}
+
+// When specifies a condition that determines whether the transformation
+// should be performed. If the condition is false, the value is passed to
+// the following rules unchanged.
+func (r TransformRule) When(condition bool) TransformRule {
+	r.condition = condition
+	return r
+}
+
+// stringTransformer returns [TransformFunc] applying fn to strings, byte
+// slices, and pointers to them.
+func stringTransformer(fn func(string) string) TransformFunc {
+	var tfn TransformFunc
+	tfn = func(v any) (any, error) {
+		if isNil, _ := IsNil(v); isNil {
+			return v, nil
+		}
+		rv := reflect.ValueOf(v)
+		switch {
+		case rv.Kind() == reflect.String:
+			str := reflect.ValueOf(fn(rv.String()))
+			return str.Convert(rv.Type()).Interface(), nil
+
+		case rv.Kind() == reflect.Slice && rv.Type().ConvertibleTo(bytesType):
+			bs := reflect.ValueOf([]byte(fn(string(rv.Bytes()))))
+			return bs.Convert(rv.Type()).Interface(), nil
+
+		case rv.Kind() == reflect.Ptr:
+			nv, err := tfn(rv.Elem().Interface())
+			if err != nil {
+				return nil, err
+			}
+			ptr := reflect.New(rv.Elem().Type())
+			ptr.Elem().Set(reflect.ValueOf(nv))
+			return ptr.Interface(), nil
+		}
+		return nil, xrr.New("must be either a string or byte slice", ECInvType)
+	}
+	return tfn
+}
+
+// collapseSpace trims the string and replaces all white space sequences with
+// a single space.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
@@ -0,0 +1,278 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+)
+
+func Test_Transformers_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		rule TransformRule
+		val  any
+		want any
+	}{
+		{"Trim", Trim, "  abc \t\n", "abc"},
+		{"Trim bytes", Trim, []byte(" abc "), []byte("abc")},
+		{"ToLower", ToLower, "ABC", "abc"},
+		{"ToUpper", ToUpper, "abc", "ABC"},
+		{"CollapseSpace", CollapseSpace, " a  b \t c ", "a b c"},
+		{"NormalizeNFC", NormalizeNFC, "e\u0301", "\u00e9"},
+		{"nil", Trim, nil, nil},
+		{"nil pointer", Trim, pStringNil, pStringNil},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have, err := tc.rule.Transform(tc.val)
+
+			// --- Then ---
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_TransformString(t *testing.T) {
+	t.Run("named string type", func(t *testing.T) {
+		// --- Given ---
+		type name string
+
+		// --- When ---
+		have, err := Trim.Transform(name(" abc "))
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, name("abc"), have)
+	})
+
+	t.Run("named byte slice type", func(t *testing.T) {
+		// --- Given ---
+		val := json.RawMessage(` {"a":1} `)
+
+		// --- When ---
+		have, err := Trim.Transform(val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, json.RawMessage(`{"a":1}`), have)
+	})
+
+	t.Run("pointer to named byte slice type", func(t *testing.T) {
+		// --- Given ---
+		val := json.RawMessage(` {"a":1} `)
+
+		// --- When ---
+		have, err := Trim.Transform(&val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+		hp, _ := have.(*json.RawMessage)
+		assert.NotNil(t, hp)
+		assert.Equal(t, json.RawMessage(`{"a":1}`), *hp)
+	})
+
+	t.Run("pointer is not modified", func(t *testing.T) {
+		// --- Given ---
+		val := " abc "
+
+		// --- When ---
+		have, err := Trim.Transform(&val)
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, " abc ", val)
+		hp, _ := have.(*string)
+		assert.NotNil(t, hp)
+		assert.Equal(t, "abc", *hp)
+	})
+
+	t.Run("error - not a string", func(t *testing.T) {
+		// --- When ---
+		have, err := Trim.Transform(123)
+
+		// --- Then ---
+		assert.Nil(t, have)
+		wMsg := "must be either a string or byte slice (ECInvType)"
+		xrrtest.AssertEqual(t, wMsg, err)
+	})
+}
+
+func Test_Transform(t *testing.T) {
+	t.Run("custom function", func(t *testing.T) {
+		// --- Given ---
+		fn := func(v any) (any, error) { return v.(int) * 2, nil }
+
+		// --- When ---
+		have, err := Transform(fn).Transform(21)
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, 42, have)
+	})
+
+	t.Run("custom function error", func(t *testing.T) {
+		// --- Given ---
+		fn := func(v any) (any, error) { return nil, ErrTst }
+
+		// --- When ---
+		err := Transform(fn).Validate(21)
+
+		// --- Then ---
+		assert.ErrorIs(t, ErrTst, err)
+	})
+
+	t.Run("nil function", func(t *testing.T) {
+		// --- When ---
+		have, err := Transform(nil).Transform("abc")
+
+		// --- Then ---
+		assert.Nil(t, have)
+		assert.ErrorIs(t, ErrInvSetup, err)
+	})
+}
+
+func Test_TransformRule_When(t *testing.T) {
+	t.Run("false", func(t *testing.T) {
+		// --- When ---
+		have, err := Trim.When(false).Transform(" abc ")
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, " abc ", have)
+	})
+
+	t.Run("true", func(t *testing.T) {
+		// --- When ---
+		have, err := Trim.When(true).Transform(" abc ")
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", have)
+	})
+}
+
+func Test_Validate_transformers(t *testing.T) {
+	t.Run("transformed value passed to following rules", func(t *testing.T) {
+		// --- When ---
+		err := Validate("  ", Trim, Required)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "cannot be blank (ECRequired)", err)
+	})
+
+	t.Run("chained transformers", func(t *testing.T) {
+		// --- When ---
+		err := Validate("  ABC ", Trim, ToLower, Equal("abc"))
+
+		// --- Then ---
+		assert.NoError(t, err)
+	})
+
+	t.Run("transformer error", func(t *testing.T) {
+		// --- When ---
+		err := Validate(123, Trim, Required)
+
+		// --- Then ---
+		xrrtest.AssertCode(t, ECInvType, err)
+	})
+
+	t.Run("with each", func(t *testing.T) {
+		// --- Given ---
+		val := []string{" a ", "  "}
+
+		// --- When ---
+		err := Each(Trim, Required).Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "1: cannot be blank (ECRequired)", err)
+		assert.Equal(t, []string{" a ", "  "}, val)
+	})
+}
+
+func Test_ValidateStruct_transformers(t *testing.T) {
+	t.Run("writes back transformed value", func(t *testing.T) {
+		// --- Given ---
+		ts := &TwoStr{FStr: "  Abc  Def "}
+
+		// --- When ---
+		err := ValidateStruct(
+			ts,
+			Field(&ts.FStr, CollapseSpace, ToLower, Required, Length(1, 7)),
+		)
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, "abc def", ts.FStr)
+	})
+
+	t.Run("writes back transformed pointer value", func(t *testing.T) {
+		// --- Given ---
+		val := " abc "
+		ts := &TwoStr{FStrPtr: &val}
+
+		// --- When ---
+		err := ValidateStruct(ts, Field(&ts.FStrPtr, Trim, Length(3, 3)))
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", *ts.FStrPtr)
+		assert.Equal(t, " abc ", val)
+	})
+
+	t.Run("writes back transformed value on error", func(t *testing.T) {
+		// --- Given ---
+		ts := &TwoStr{FStr: "  "}
+
+		// --- When ---
+		err := ValidateStruct(ts, Field(&ts.FStr, Trim, Required))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "FStr: cannot be blank (ECRequired)", err)
+		assert.Equal(t, "", ts.FStr)
+	})
+
+	t.Run("not assignable value is not written back", func(t *testing.T) {
+		// --- Given ---
+		ts := &TwoStr{FStr: "abc"}
+		fn := func(v any) (any, error) { return len(v.(string)), nil }
+
+		// --- When ---
+		err := ValidateStruct(ts, Field(&ts.FStr, Transform(fn), Equal(3)))
+
+		// --- Then ---
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", ts.FStr)
+	})
+
+	t.Run("transformer error", func(t *testing.T) {
+		// --- Given ---
+		ts := &TwoStr{FStr: "abc"}
+		fn := func(v any) (any, error) { return nil, errors.New("tr error") }
+
+		// --- When ---
+		err := ValidateStruct(ts, Field(&ts.FStr, Transform(fn)))
+
+		// --- Then ---
+		xrrtest.AssertEqual(t, "FStr: tr error (ECGeneric)", err)
+		assert.Equal(t, "abc", ts.FStr)
+	})
+}
+
+func Test_collapseSpace(t *testing.T) {
+	// --- When ---
+	have := collapseSpace(strings.Repeat(" a\t", 3))
+
+	// --- Then ---
+	assert.Equal(t, "a a a", have)
+}
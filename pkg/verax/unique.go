@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"reflect"
+
+	"github.com/ctx42/xrr/pkg/xrr"
+)
+
+// ECNotUnique represents error code for a not unique value.
+const ECNotUnique = "ECNotUnique"
+
+// UniqueFirst is the name of the error metadata key holding the index (or the
+// map key) of the first occurrence of a duplicated value.
+const UniqueFirst = "first"
+
+// ErrNotUnique is the error returned for duplicated values.
+var ErrNotUnique = xrr.New("must be unique", ECNotUnique)
+
+// KeyFunc is a function returning a key used to compare values.
+type KeyFunc func(v any) any
+
+// Unique returns a validation rule that checks all elements of an iterable
+// (map, slice or array) are distinct. Comparable elements are compared using
+// the == operator, all other elements are compared using [reflect.DeepEqual].
+//
+// Duplicates are reported under the indices (or the map keys) of the later
+// occurrences, each error has [UniqueFirst] metadata key set to the index (or
+// the map key) of the first occurrence. Map values are visited in the order of
+// their sorted keys. An empty iterable is considered valid.
+func Unique() UniqueRule {
+	return UniqueRule{condition: true, err: ErrNotUnique}
+}
+
+// UniqueBy returns a validation rule that works the same way as [Unique] but
+// compares the keys returned by the given function for each element.
+//
+// Example:
+//
+//	// Users must have unique emails.
+//	rule := UniqueBy(func(v any) any { return v.(User).Email })
+func UniqueBy(fn KeyFunc) UniqueRule {
+	return UniqueRule{key: fn, condition: true, err: ErrNotUnique}
+}
+
+// Compile time checks.
+var (
+	_ Customizer[UniqueRule]  = UniqueRule{}
+	_ Conditioner[UniqueRule] = UniqueRule{}
+)
+
+// UniqueRule is a validation rule that checks all elements of a map, slice,
+// or array are distinct.
+type UniqueRule struct {
+	key       KeyFunc // Function returning the element key.
+	condition bool    // Run validation only when true.
+	err       error   // Validation error.
+}
+
+// Validate checks if the given value is valid or not.
+func (r UniqueRule) Validate(v any) error {
+	if !r.condition {
+		return nil
+	}
+
+	var names []string
+	var values []any
+	collect := func(name string, val any) bool {
+		names = append(names, name)
+		values = append(values, val)
+		return true
+	}
+	if !iterate(v, collect) {
+		return xrr.New("must be an iterable", ECInvType)
+	}
+
+	keys := make([]any, len(values))
+	for i, val := range values {
+		keys[i] = val
+		if r.key != nil {
+			keys[i] = r.key(val)
+		}
+	}
+
+	var ers xrr.Fields
+	seen := make(map[any]int, len(keys)) // Comparable keys.
+	var others []int                     // Indexes of not comparable keys.
+	for i, key := range keys {
+		first := -1
+		if key == nil || reflect.ValueOf(key).Comparable() {
+			if idx, ok := seen[key]; ok {
+				first = idx
+			} else {
+				seen[key] = i
+			}
+		} else {
+			for _, idx := range others {
+				if reflect.DeepEqual(keys[idx], key) {
+					first = idx
+					break
+				}
+			}
+			if first == -1 {
+				others = append(others, i)
+			}
+		}
+
+		if first >= 0 {
+			if ers == nil {
+				ers = xrr.Fields{}
+			}
+			ers[names[i]] = r.duplicate(names[first])
+		}
+	}
+
+	if len(ers) > 0 {
+		return ers
+	}
+	return nil
+}
+
+// When specifies a condition that determines whether validation should be
+// performed. If the condition is false, validation is skipped, and no errors
+// are reported.
+func (r UniqueRule) When(condition bool) UniqueRule {
+	r.condition = condition
+	return r
+}
+
+// Code sets the error code for the rule.
+func (r UniqueRule) Code(code string) UniqueRule {
+	r.err = setCode(r.err, code)
+	return r
+}
+
+// Error sets custom error for the rule.
+func (r UniqueRule) Error(err error) UniqueRule {
+	r.err = err
+	return r
+}
+
+// duplicate returns an error for duplicated value with the first occurrence
+// set as the error metadata.
+func (r UniqueRule) duplicate(first string) error {
+	return xrr.Wrap(r.err, xrr.Meta().Str(UniqueFirst, first).Option())
+}
@@ -0,0 +1,165 @@
+// SPDX-FileCopyrightText: (c) 2025 Rafal Zajac <rzajac@gmail.com>
+// SPDX-License-Identifier: MIT
+
+package verax
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ctx42/testing/pkg/assert"
+	"github.com/ctx42/xrr/pkg/xrr"
+	"github.com/ctx42/xrr/pkg/xrr/xrrtest"
+)
+
+func Test_UniqueRule_Validate_valid_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		val  any
+		rule UniqueRule
+	}{
+		{"nil slice", []int(nil), Unique()},
+		{"empty slice", []int{}, Unique()},
+		{"slice of int", []int{1, 2, 3}, Unique()},
+		{"slice of string", []string{"a", "b", "c"}, Unique()},
+		{"slice of slices", [][]int{{1}, {1, 2}, {2}}, Unique()},
+		{"slice of any", []any{1, "1", []int{1}, nil}, Unique()},
+		{"array of int", [...]int{1, 2, 3}, Unique()},
+		{"map string:int", map[string]int{"A": 1, "B": 2}, Unique()},
+		{"nil map", map[string]int(nil), Unique()},
+		{
+			"by key",
+			[]TwoStr{{FStr: "a"}, {FStr: "b"}},
+			UniqueBy(func(v any) any { return v.(TwoStr).FStr }),
+		},
+		{"condition false", []int{1, 1}, Unique().When(false)},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := tc.rule.Validate(tc.val)
+
+			// --- Then ---
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_UniqueRule_Validate_invalid_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		val  any
+		rule UniqueRule
+		err  string
+	}{
+		{
+			"not iterable",
+			"abc",
+			Unique(),
+			"must be an iterable (ECInvType)",
+		},
+		{
+			"slice of int",
+			[]int{1, 2, 1, 3, 1},
+			Unique(),
+			"2: must be unique (ECNotUnique); 4: must be unique (ECNotUnique)",
+		},
+		{
+			"slice of slices",
+			[][]int{{1}, {2}, {1}},
+			Unique(),
+			"2: must be unique (ECNotUnique)",
+		},
+		{
+			"slice of nil values",
+			[]*int{nil, pInt, nil},
+			Unique(),
+			"2: must be unique (ECNotUnique)",
+		},
+		{
+			"array of string",
+			[...]string{"a", "a"},
+			Unique(),
+			"1: must be unique (ECNotUnique)",
+		},
+		{
+			"map string:int",
+			map[string]int{"C": 1, "B": 2, "A": 1},
+			Unique(),
+			"C: must be unique (ECNotUnique)",
+		},
+		{
+			"by key",
+			[]TwoStr{{FStr: "a"}, {FStr: "b"}, {FStr: "a"}},
+			UniqueBy(func(v any) any { return v.(TwoStr).FStr }),
+			"2: must be unique (ECNotUnique)",
+		},
+		{
+			"by not comparable key",
+			[]string{"a b", "c", "a  b"},
+			UniqueBy(func(v any) any { return strings.Fields(v.(string)) }),
+			"2: must be unique (ECNotUnique)",
+		},
+		{
+			"custom code",
+			[]int{1, 1},
+			Unique().Code("ECCustom"),
+			"1: must be unique (ECCustom)",
+		},
+		{
+			"custom error",
+			[]int{1, 1},
+			Unique().Error(ErrTst),
+			"1: tst msg (ETstCode)",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			err := tc.rule.Validate(tc.val)
+
+			// --- Then ---
+			xrrtest.AssertEqual(t, tc.err, err)
+		})
+	}
+}
+
+func Test_UniqueRule_Validate(t *testing.T) {
+	t.Run("first occurrence metadata", func(t *testing.T) {
+		// --- Given ---
+		val := []string{"a", "b", "a", "b", "a"}
+
+		// --- When ---
+		err := Unique().Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertFieldCnt(t, 3, err)
+		xrrtest.AssertStr(t, UniqueFirst, "0", xrr.GetFieldError(err, "2"))
+		xrrtest.AssertStr(t, UniqueFirst, "1", xrr.GetFieldError(err, "3"))
+		xrrtest.AssertStr(t, UniqueFirst, "0", xrr.GetFieldError(err, "4"))
+	})
+
+	t.Run("first occurrence metadata for map", func(t *testing.T) {
+		// --- Given ---
+		val := map[int]string{10: "a", 2: "a"}
+
+		// --- When ---
+		err := Unique().Validate(val)
+
+		// --- Then ---
+		xrrtest.AssertFieldCnt(t, 1, err)
+		xrrtest.AssertStr(t, UniqueFirst, "2", xrr.GetFieldError(err, "10"))
+	})
+
+	t.Run("error is ErrNotUnique", func(t *testing.T) {
+		// --- When ---
+		err := Unique().Validate([]int{1, 1})
+
+		// --- Then ---
+		xrrtest.AssertFieldIs(t, "1", ErrNotUnique, err)
+	})
+}
@@ -4,9 +4,11 @@
 package verax
 
 import (
+	"cmp"
 	"database/sql/driver"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"text/template"
 	"time"
@@ -234,6 +236,127 @@ func mapErrKey(value reflect.Value) string {
 	}
 }
 
+// sortedMapKeys returns keys of the map sorted in the ascending order. Signed
+// integer keys go first, then unsigned integer keys, both sorted as numbers,
+// and then all other keys sorted by their [mapErrKey] representation.
+func sortedMapKeys(value reflect.Value) []reflect.Value {
+	keys := value.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int {
+		ai, bi := getInterface(a), getInterface(b)
+		ia, errIA := ToInt(ai)
+		ib, errIB := ToInt(bi)
+		ua, errUA := ToUint(ai)
+		ub, errUB := ToUint(bi)
+		switch {
+		case errIA == nil && errIB == nil:
+			return cmp.Compare(ia, ib)
+		case errIA == nil:
+			return -1
+		case errIB == nil:
+			return 1
+		case errUA == nil && errUB == nil:
+			return cmp.Compare(ua, ub)
+		case errUA == nil:
+			return -1
+		case errUB == nil:
+			return 1
+		}
+		return cmp.Compare(mapErrKey(a), mapErrKey(b))
+	})
+	return keys
+}
+
+// iterate calls fn with the name and the value of each element of the
+// iterable (map, slice, array, [iter.Seq] or [iter.Seq2]). Map elements are
+// visited in the order of their sorted keys, and named using [mapErrKey].
+// Slice and array elements are named by their indexes. See [rangeFunc] for
+// iterator element names. The iteration stops when fn returns false.
+// Returns false if v is not an iterable.
+func iterate(v any, fn func(name string, val any) bool) bool {
+	vo := reflect.ValueOf(v)
+	switch vo.Kind() { // nolint: exhaustive
+	case reflect.Map:
+		for _, k := range sortedMapKeys(vo) {
+			if !fn(mapErrKey(k), getInterface(vo.MapIndex(k))) {
+				break
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < vo.Len(); i++ {
+			if !fn(strconv.Itoa(i), getInterface(vo.Index(i))) {
+				break
+			}
+		}
+
+	case reflect.Func:
+		return rangeFunc(vo, func(name string, val reflect.Value) bool {
+			return fn(name, getInterface(val))
+		})
+
+	default:
+		return false
+	}
+	return true
+}
+
+// seqLen returns 1 for [iter.Seq] and 2 for [iter.Seq2] function types. For
+// all other types, it returns 0.
+func seqLen(typ reflect.Type) int {
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || typ.NumOut() != 0 {
+		return 0
+	}
+	yield := typ.In(0)
+	if yield.Kind() != reflect.Func || yield.NumOut() != 1 ||
+		yield.Out(0).Kind() != reflect.Bool {
+
+		return 0
+	}
+	if n := yield.NumIn(); n == 1 || n == 2 {
+		return n
+	}
+	return 0
+}
+
+// rangeFunc calls fn with the name and the value of each element yielded by
+// the [iter.Seq] or [iter.Seq2] iterator. Elements yielded by [iter.Seq] are
+// named by their positions, elements yielded by [iter.Seq2] are named by
+// their keys using [mapErrKey]. The iteration stops when fn returns false.
+// A nil iterator yields no elements. Returns false if vo is not an iterator.
+func rangeFunc(
+	vo reflect.Value,
+	fn func(name string, val reflect.Value) bool,
+) bool {
+
+	switch seqLen(vo.Type()) {
+	case 1:
+		if vo.IsNil() {
+			return true
+		}
+		var i int
+		for val := range vo.Seq() {
+			if !fn(strconv.Itoa(i), val) {
+				break
+			}
+			i++
+		}
+
+	case 2:
+		if vo.IsNil() {
+			return true
+		}
+		for key, val := range vo.Seq2() {
+			if !fn(mapErrKey(key), val) {
+				break
+			}
+		}
+
+	default:
+		return false
+	}
+	return true
+}
+
 // emtpl returns parsed error message template. Panics on error.
 func emtpl(tpl string) *template.Template {
 	return template.Must(template.New("").Parse(tpl))
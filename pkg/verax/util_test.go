@@ -5,7 +5,10 @@ package verax
 
 import (
 	"database/sql"
+	"iter"
+	"maps"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -587,6 +590,182 @@ func Test_mapErrKey_tabular(t *testing.T) {
 	}
 }
 
+func Test_sortedMapKeys(t *testing.T) {
+	t.Run("int keys", func(t *testing.T) {
+		// --- Given ---
+		val := reflect.ValueOf(map[int]int{10: 0, 2: 0, -1: 0})
+
+		// --- When ---
+		have := sortedMapKeys(val)
+
+		// --- Then ---
+		assert.Len(t, 3, have)
+		assert.Equal(t, -1, have[0].Interface())
+		assert.Equal(t, 2, have[1].Interface())
+		assert.Equal(t, 10, have[2].Interface())
+	})
+
+	t.Run("uint keys", func(t *testing.T) {
+		// --- Given ---
+		val := reflect.ValueOf(map[uint]int{10: 0, 2: 0})
+
+		// --- When ---
+		have := sortedMapKeys(val)
+
+		// --- Then ---
+		assert.Len(t, 2, have)
+		assert.Equal(t, uint(2), have[0].Interface())
+		assert.Equal(t, uint(10), have[1].Interface())
+	})
+
+	t.Run("string keys", func(t *testing.T) {
+		// --- Given ---
+		val := reflect.ValueOf(map[string]int{"b": 0, "c": 0, "a": 0})
+
+		// --- When ---
+		have := sortedMapKeys(val)
+
+		// --- Then ---
+		assert.Len(t, 3, have)
+		assert.Equal(t, "a", have[0].Interface())
+		assert.Equal(t, "b", have[1].Interface())
+		assert.Equal(t, "c", have[2].Interface())
+	})
+
+	t.Run("mixed type keys", func(t *testing.T) {
+		// --- Given ---
+		val := reflect.ValueOf(map[any]int{
+			"1a": 0, uint(1): 0, 10: 0, "b": 0, 2: 0, uint8(0): 0,
+		})
+
+		// --- When ---
+		have := sortedMapKeys(val)
+
+		// --- Then ---
+		assert.Len(t, 6, have)
+		assert.Equal(t, 2, have[0].Interface())
+		assert.Equal(t, 10, have[1].Interface())
+		assert.Equal(t, uint8(0), have[2].Interface())
+		assert.Equal(t, uint(1), have[3].Interface())
+		assert.Equal(t, "1a", have[4].Interface())
+		assert.Equal(t, "b", have[5].Interface())
+	})
+}
+
+func Test_seqLen_tabular(t *testing.T) {
+	tt := []struct {
+		testN string
+
+		val  any
+		want int
+	}{
+		{"iter.Seq", slices.Values([]int{}), 1},
+		{"iter.Seq2", slices.All([]int{}), 2},
+		{"not named iter.Seq", func(func(int) bool) {}, 1},
+		{"not a function", 1, 0},
+		{"no arguments", func() {}, 0},
+		{"with return", func(func(int) bool) int { return 0 }, 0},
+		{"not a yield function", func(int) {}, 0},
+		{"yield without return", func(func(int)) {}, 0},
+		{"yield returning int", func(func(int) int) {}, 0},
+		{"yield without arguments", func(func() bool) {}, 0},
+		{"yield with 3 arguments", func(func(int, int, int) bool) {}, 0},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testN, func(t *testing.T) {
+			// --- When ---
+			have := seqLen(reflect.TypeOf(tc.val))
+
+			// --- Then ---
+			assert.Equal(t, tc.want, have)
+		})
+	}
+}
+
+func Test_rangeFunc(t *testing.T) {
+	t.Run("iter.Seq", func(t *testing.T) {
+		// --- Given ---
+		seq := slices.Values([]string{"a", "b", "c"})
+
+		var names []string
+		var values []any
+		fn := func(name string, val reflect.Value) bool {
+			names = append(names, name)
+			values = append(values, val.Interface())
+			return true
+		}
+
+		// --- When ---
+		have := rangeFunc(reflect.ValueOf(seq), fn)
+
+		// --- Then ---
+		assert.True(t, have)
+		assert.Equal(t, []string{"0", "1", "2"}, names)
+		assert.Equal(t, []any{"a", "b", "c"}, values)
+	})
+
+	t.Run("iter.Seq2", func(t *testing.T) {
+		// --- Given ---
+		seq := maps.All(map[string]int{"a": 1})
+
+		var names []string
+		var values []any
+		fn := func(name string, val reflect.Value) bool {
+			names = append(names, name)
+			values = append(values, val.Interface())
+			return true
+		}
+
+		// --- When ---
+		have := rangeFunc(reflect.ValueOf(seq), fn)
+
+		// --- Then ---
+		assert.True(t, have)
+		assert.Equal(t, []string{"a"}, names)
+		assert.Equal(t, []any{1}, values)
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		// --- Given ---
+		seq := slices.All([]string{"a", "b", "c"})
+
+		var names []string
+		fn := func(name string, _ reflect.Value) bool {
+			names = append(names, name)
+			return false
+		}
+
+		// --- When ---
+		have := rangeFunc(reflect.ValueOf(seq), fn)
+
+		// --- Then ---
+		assert.True(t, have)
+		assert.Equal(t, []string{"0"}, names)
+	})
+
+	t.Run("nil iterators", func(t *testing.T) {
+		// --- Given ---
+		fn := func(string, reflect.Value) bool { panic("not expected") }
+
+		// --- When ---
+		have1 := rangeFunc(reflect.ValueOf(iter.Seq[int](nil)), fn)
+		have2 := rangeFunc(reflect.ValueOf(iter.Seq2[int, int](nil)), fn)
+
+		// --- Then ---
+		assert.True(t, have1)
+		assert.True(t, have2)
+	})
+
+	t.Run("not an iterator", func(t *testing.T) {
+		// --- When ---
+		have := rangeFunc(reflect.ValueOf(func() {}), nil)
+
+		// --- Then ---
+		assert.False(t, have)
+	})
+}
+
 func Test_emtpl(t *testing.T) {
 	t.Run("ok", func(t *testing.T) {
 		// --- Given ---
@@ -116,20 +116,38 @@ var validatableType = reflect.TypeOf((*Validator)(nil)).Elem()
 // arrays, pointers, or interfaces with validatable elements. Returns nil for
 // nil pointers or interfaces.
 //
-// nolint: cyclop
+// Rules implementing the [Transformer] interface replace the validated value
+// with the transformed one for all the rules following them.
 func Validate(v any, rules ...Rule) error {
+	_, err := validate(v, rules...)
+	return err
+}
+
+// validate works like [Validate] but also returns the value after all
+// [Transformer] rules were applied to it.
+//
+// nolint: cyclop
+func validate(v any, rules ...Rule) (any, error) {
 	for _, rule := range rules {
 		if s, ok := rule.(skipRule); ok && bool(s) {
-			return nil
+			return v, nil
+		}
+		if tr, ok := rule.(Transformer); ok {
+			tv, err := tr.Transform(v)
+			if err != nil {
+				return v, err
+			}
+			v = tv
+			continue
 		}
 		if red, ok := v.(WithValidator); ok {
 			if err := red.ValidateWith(rule); err != nil {
-				return err
+				return v, err
 			}
 			continue
 		}
 		if err := rule.Validate(v); err != nil {
-			return err
+			return v, err
 		}
 	}
 
@@ -137,30 +155,30 @@ func Validate(v any, rules ...Rule) error {
 	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface) &&
 		rv.IsNil() {
 
-		return nil
+		return v, nil
 	}
 
 	if vi, ok := v.(Validator); ok {
-		return vi.Validate()
+		return v, vi.Validate()
 	}
 
 	//goland:noinspection GoSwitchMissingCasesForIotaConsts
 	switch rv.Kind() { // nolint: exhaustive
 	case reflect.Map:
 		if rv.Type().Elem().Implements(validatableType) {
-			return validateMap(rv)
+			return v, validateMap(rv)
 		}
 
 	case reflect.Slice, reflect.Array:
 		if rv.Type().Elem().Implements(validatableType) {
-			return validateSlice(rv)
+			return v, validateSlice(rv)
 		}
 
 	case reflect.Ptr, reflect.Interface:
-		return Validate(rv.Elem().Interface())
+		return v, Validate(rv.Elem().Interface())
 	}
 
-	return nil
+	return v, nil
 }
 
 // ValidateNamed validates v using the provided rules, wrapping any error in